@@ -0,0 +1,195 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppendAll appends values to the array at keyPath in a single operation.
+// If the path does not exist, a new array holding values is created, along with any missing
+// intermediate objects. Returns an error if the existing value at keyPath is not an array.
+func (j *JsonMapper) AppendAll(keyPath string, values ...interface{}) error {
+	return j.mutate("append", keyPath, values, func() error {
+		return j.edit(keyPath, true, func(old interface{}, exists bool) (interface{}, error) {
+			if !exists {
+				return append([]interface{}{}, values...), nil
+			}
+			s, ok := old.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: value at %s is not a slice", ErrTypeMismatch, keyPath)
+			}
+			return append(s, values...), nil
+		})
+	})
+}
+
+// InsertAt inserts value into the array at keyPath before position index, shifting the following
+// elements to the right. Unlike Add with an index, no existing element is overwritten.
+// An index equal to the array length or -1 appends the value.
+// Returns an error if the value at keyPath is not an array or index is out of range.
+func (j *JsonMapper) InsertAt(keyPath string, index int, value interface{}) error {
+	return j.mutate("insert", keyPath, value, func() error {
+		return j.editArray(keyPath, func(s []interface{}) ([]interface{}, error) {
+			if index == -1 {
+				index = len(s)
+			}
+			if index < 0 || index > len(s) {
+				return nil, fmt.Errorf("%w: %d", ErrIndexOutOfRange, index)
+			}
+			updated := make([]interface{}, 0, len(s)+1)
+			updated = append(updated, s[:index]...)
+			updated = append(updated, value)
+			return append(updated, s[index:]...), nil
+		})
+	})
+}
+
+// SortArray sorts the array at keyPath in place using a stable sort.
+// For arrays of objects, byField names the field to sort by and may itself be a keyPath
+// relative to each element (e.g. "meta.created"); elements lacking the field are placed last.
+// For arrays of plain values, byField must be empty and the values themselves are compared.
+// Numbers are compared numerically, strings lexicographically and false sorts before true;
+// values of different kinds are ordered by kind (null, bool, number, string, array, object).
+// Returns an error if the value at keyPath is not an array.
+func (j *JsonMapper) SortArray(keyPath string, byField string, desc bool) error {
+	fieldKeys := splitKeyPath(byField)
+	return j.mutate("sort", keyPath, byField, func() error {
+		return j.editArray(keyPath, func(s []interface{}) ([]interface{}, error) {
+			sorted := append([]interface{}(nil), s...)
+			sort.SliceStable(sorted, func(a, b int) bool {
+				va, okA := lookupIn(sorted[a], fieldKeys)
+				vb, okB := lookupIn(sorted[b], fieldKeys)
+				if !okA || !okB {
+					return okA && !okB
+				}
+				if desc {
+					return compareValues(va, vb) > 0
+				}
+				return compareValues(va, vb) < 0
+			})
+			return sorted, nil
+		})
+	})
+}
+
+// ReverseArray reverses the order of the elements of the array at keyPath.
+// Returns an error if the value at keyPath is not an array.
+func (j *JsonMapper) ReverseArray(keyPath string) error {
+	return j.mutate("reverse", keyPath, nil, func() error {
+		return j.editArray(keyPath, func(s []interface{}) ([]interface{}, error) {
+			reversed := make([]interface{}, len(s))
+			for i, v := range s {
+				reversed[len(s)-1-i] = v
+			}
+			return reversed, nil
+		})
+	})
+}
+
+// FilterArray removes every element of the array at keyPath that does not satisfy conditions,
+// using the same condition format as FindAllWithCondition. A scalar element is kept if it satisfies
+// the conditions; an object or array element is kept if any value nested within it does.
+// Returns an error if the value at keyPath is not an array.
+func (j *JsonMapper) FilterArray(keyPath string, conditions interface{}) error {
+	conditions, err := compileConditions(conditions)
+	if err != nil {
+		return err
+	}
+	return j.mutate("filter", keyPath, conditions, func() error {
+		return j.editArray(keyPath, func(s []interface{}) ([]interface{}, error) {
+			filtered := make([]interface{}, 0, len(s))
+			for i, v := range s {
+				if j.matchesCondition(v, strconv.Itoa(i), conditions) {
+					filtered = append(filtered, v)
+				}
+			}
+			return filtered, nil
+		})
+	})
+}
+
+// MapArray replaces every element of the array at keyPath with the result of fn,
+// which receives the element index and its current value, e.g. to normalize every object in an array.
+// Returns an error if the value at keyPath is not an array.
+func (j *JsonMapper) MapArray(keyPath string, fn func(i int, v interface{}) interface{}) error {
+	return j.mutate("map", keyPath, nil, func() error {
+		return j.editArray(keyPath, func(s []interface{}) ([]interface{}, error) {
+			mapped := make([]interface{}, len(s))
+			for i, v := range s {
+				mapped[i] = fn(i, v)
+			}
+			return mapped, nil
+		})
+	})
+}
+
+// compareValues orders two JSON values, returning a negative number if a sorts before b,
+// zero if they are equivalent and a positive number otherwise.
+func compareValues(a, b interface{}) int {
+	rankA, rankB := kindRank(a), kindRank(b)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+
+	switch {
+	case isNumeric(a):
+		fa, _ := convertToFloat64(a)
+		fb, _ := convertToFloat64(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case kindOf(a) == KindString:
+		return strings.Compare(a.(string), b.(string))
+	case kindOf(a) == KindBool:
+		switch {
+		case a == b:
+			return 0
+		case a == false:
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+// kindRank returns the position of a value's kind in the cross-kind sort order used by compareValues.
+func kindRank(v interface{}) int {
+	switch kindOf(v) {
+	case KindNull:
+		return 0
+	case KindBool:
+		return 1
+	case KindNumber:
+		return 2
+	case KindString:
+		return 3
+	case KindArray:
+		return 4
+	case KindObject:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// editArray replaces the array at keyPath with the result of fn.
+// Returns an error if the path does not exist or its value is not an array.
+func (j *JsonMapper) editArray(keyPath string, fn func(s []interface{}) ([]interface{}, error)) error {
+	return j.edit(keyPath, false, func(old interface{}, exists bool) (interface{}, error) {
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyPath)
+		}
+		s, ok := old.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: value at %s is not a slice", ErrTypeMismatch, keyPath)
+		}
+		return fn(s)
+	})
+}
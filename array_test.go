@@ -0,0 +1,106 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAppendAll(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	if err := j.AppendAll("testData.sliced", 6.0, 7.0); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("testData.sliced"); !reflect.DeepEqual(s, []interface{}{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0}) {
+		t.Errorf("got %v", s)
+	}
+	if err := j.AppendAll("testData.tags", "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("testData.tags"); !reflect.DeepEqual(s, []interface{}{"a", "b"}) {
+		t.Errorf("new array: got %v", s)
+	}
+	if err := j.AppendAll("testData.string", 1); err == nil {
+		t.Error("expected error appending to a string")
+	}
+}
+
+func TestInsertAt(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": [1, 2, 3]}`)
+
+	_ = j.InsertAt("a", 0, 0.0)
+	_ = j.InsertAt("a", 2, 1.5)
+	_ = j.InsertAt("a", -1, 4.0)
+	if s, _ := j.FindSlice("a"); !reflect.DeepEqual(s, []interface{}{0.0, 1.0, 1.5, 2.0, 3.0, 4.0}) {
+		t.Errorf("got %v", s)
+	}
+	if err := j.InsertAt("a", 7, 0.0); err == nil {
+		t.Error("expected out of range error")
+	}
+}
+
+func TestSortArray(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"s2": [{"id": 2, "name": "bob"}, {"name": "zed"}, {"id": 3, "name": "cindy"}, {"id": 1, "name": "alice"}], "plain": [3, "b", 1, "a", null]}`)
+
+	if err := j.SortArray("s2", "id", true); err != nil {
+		t.Fatal(err)
+	}
+	var names []interface{}
+	for i := 0; i < 4; i++ {
+		names = append(names, j.FindStringOr(fmt.Sprintf("s2.%d.name", i), ""))
+	}
+	if !reflect.DeepEqual(names, []interface{}{"cindy", "bob", "alice", "zed"}) {
+		t.Errorf("by field: got %v", names)
+	}
+
+	if err := j.SortArray("plain", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("plain"); !reflect.DeepEqual(s, []interface{}{nil, 1.0, 3.0, "a", "b"}) {
+		t.Errorf("plain: got %v", s)
+	}
+}
+
+func TestReverseArray(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": [1, 2, 3]}`)
+
+	if err := j.ReverseArray("a"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("a"); !reflect.DeepEqual(s, []interface{}{3.0, 2.0, 1.0}) {
+		t.Errorf("got %v", s)
+	}
+}
+
+func TestFilterArray(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	if err := j.FilterArray("testData.sliced", map[string]interface{}{"gt": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("testData.sliced"); !reflect.DeepEqual(s, []interface{}{3.0, 4.0, 5.0}) {
+		t.Errorf("scalars: got %v", s)
+	}
+
+	if err := j.FilterArray("testData.s2", map[string]interface{}{"eq": "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := j.Len("testData.s2"); n != 1 || j.FindStringOr("testData.s2.0.name", "") != "bob" {
+		t.Errorf("objects: got %v", j.FindSliceOr("testData.s2", nil))
+	}
+}
+
+func TestMapArray(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": [1, 2, 3]}`)
+
+	err := j.MapArray("a", func(i int, v interface{}) interface{} {
+		return v.(float64) * 10
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("a"); !reflect.DeepEqual(s, []interface{}{10.0, 20.0, 30.0}) {
+		t.Errorf("got %v", s)
+	}
+}
@@ -0,0 +1,141 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// AtomicWriteOptions configures WriteFileAtomic.
+type AtomicWriteOptions struct {
+	// Perm is the permission of the written file. If zero, the permissions of an existing file are kept
+	// and a new file is created with 0644, like WriteFile.
+	Perm os.FileMode
+	// BackupSuffix, if not empty, keeps the previous version of an existing file next to it
+	// under the file name with the suffix appended, e.g. "config.json.bak" for ".bak".
+	// An older backup is replaced.
+	BackupSuffix string
+}
+
+// WriteFileAtomic is like WriteFile but never leaves a partially written file behind, so a crash or a full disk
+// cannot corrupt the configuration: the document is written to a temporary file in the same directory,
+// flushed to disk with fsync and then renamed over filePath, and the directory is synced so the rename is durable.
+// Readers see either the old or the new contents, never a mix.
+// Returns an error if any step fails, in which case filePath is left unchanged and the temporary file is removed.
+func (j *JsonMapper) WriteFileAtomic(filePath string, pretty bool, opts AtomicWriteOptions) error {
+	data, err := j.marshalJSON(j.printOptions, pretty)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	perm := opts.Perm
+	existing, err := os.Stat(filePath)
+	switch {
+	case err == nil:
+		if !existing.Mode().IsRegular() {
+			return fmt.Errorf("failed to write file: %s is not a regular file", filePath)
+		}
+		if perm == 0 {
+			perm = existing.Mode().Perm()
+		}
+	case os.IsNotExist(err):
+		existing = nil
+		if perm == 0 {
+			perm = 0644
+		}
+	default:
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	dir := filepath.Dir(filePath)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	renamed := false
+	defer func() {
+		if !renamed {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	if existing != nil && opts.BackupSuffix != "" {
+		if err := backupFile(filePath, filePath+opts.BackupSuffix); err != nil {
+			return fmt.Errorf("failed to back up file: %v", err)
+		}
+	}
+
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	renamed = true
+
+	if err := syncDir(dir); err != nil {
+		return fmt.Errorf("failed to sync directory: %v", err)
+	}
+	return nil
+}
+
+// backupFile makes backupPath refer to the current contents of filePath, replacing any older backup.
+// It uses a hard link where possible, so the backup costs no copy, and falls back to copying the file.
+func backupFile(filePath, backupPath string) error {
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Link(filePath, backupPath); err == nil {
+		return nil
+	}
+
+	src, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// syncDir flushes the directory entry changes of dir to disk.
+// Windows does not support syncing directories, and renames there are already durable.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,74 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "config.json")
+
+	j, _ := NewJsonMapStr(`{"b": 1, "a": {"c": true}}`)
+	if err := j.WriteFileAtomic(filePath, false, AtomicWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filePath)
+	if string(data) != `{"a":{"c":true},"b":1}` {
+		t.Errorf("file = %s", data)
+	}
+	if info, _ := os.Stat(filePath); info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, want 0644", info.Mode().Perm())
+	}
+
+	if err := os.Chmod(filePath, 0600); err != nil {
+		t.Fatal(err)
+	}
+	_ = j.Set("b", 2.0)
+	if err := j.WriteFileAtomic(filePath, true, AtomicWriteOptions{BackupSuffix: ".bak"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(filePath); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, existing permissions not kept", info.Mode().Perm())
+	}
+	backup, err := os.ReadFile(filePath + ".bak")
+	if err != nil || string(backup) != `{"a":{"c":true},"b":1}` {
+		t.Errorf("backup = %s, %v", backup, err)
+	}
+	written, _ := NewJsonMapFile(filePath)
+	if written.FindIntOr("b", 0) != 2 {
+		t.Errorf("file = %s", written.Print())
+	}
+
+	_ = j.Set("b", 3.0)
+	if err := j.WriteFileAtomic(filePath, false, AtomicWriteOptions{Perm: 0640, BackupSuffix: ".bak"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(filePath); info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+	if backup, _ := NewJsonMapFile(filePath + ".bak"); backup.FindIntOr("b", 0) != 2 {
+		t.Errorf("backup = %s, want previous version", backup.Print())
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("directory holds %d entries, temporary files left behind?", len(entries))
+	}
+}
+
+func TestWriteFileAtomicErrors(t *testing.T) {
+	dir := t.TempDir()
+	j, _ := NewJsonMapStr(`{"a": 1}`)
+
+	if err := j.WriteFileAtomic(filepath.Join(dir, "missing", "config.json"), false, AtomicWriteOptions{}); err == nil {
+		t.Error("expected error for missing directory")
+	}
+	if err := j.WriteFileAtomic(dir, false, AtomicWriteOptions{}); err == nil {
+		t.Error("expected error for directory target")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("directory holds %d entries after failed writes", len(entries))
+	}
+}
@@ -0,0 +1,200 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Op describes a single mutation applied by JsonMapper.Apply.
+// Action is one of "add", "set" or "remove", with the same semantics as the methods of the same name.
+// Value is ignored for "remove".
+type Op struct {
+	Action string
+	Path   string
+	Value  interface{}
+}
+
+// Apply executes ops in order as a single all-or-nothing mutation.
+// The operations are applied to a working copy of the document, which replaces the document only
+// if every operation succeeds; otherwise the document is left untouched and the first error is returned.
+// Only the objects and arrays along the edited paths are copied; untouched subtrees are shared with the
+// previous document. Copied parents are resolved once and reused by later operations sharing the same prefix,
+// so many edits below the same object avoid repeated traversals.
+func (j *JsonMapper) Apply(ops []Op) error {
+	for i, op := range ops {
+		switch op.Action {
+		case "add", "set", "remove":
+		default:
+			return fmt.Errorf("op %d: unsupported action: %s", i, op.Action)
+		}
+	}
+
+	return j.mutate("apply", "", ops, func() error {
+		work, _ := shallowCopy(j.m).(map[string]interface{})
+		if work == nil {
+			work = make(map[string]interface{})
+		}
+
+		b := batch{root: work, owned: make(map[string]interface{})}
+		for i, op := range ops {
+			if err := b.apply(op); err != nil {
+				return fmt.Errorf("op %d (%s %s): %v", i, op.Action, op.Path, err)
+			}
+		}
+
+		j.m = work
+		return nil
+	})
+}
+
+// SetMany assigns every value in values to its keyPath in a single all-or-nothing mutation,
+// e.g. to apply parsed command line flags or environment overrides onto a configuration document.
+// Like Add, missing keys and intermediate objects are created. Paths are applied in sorted order,
+// so a parent path is always assigned before the paths below it.
+func (j *JsonMapper) SetMany(values map[string]interface{}) error {
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	ops := make([]Op, len(paths))
+	for i, path := range paths {
+		ops[i] = Op{Action: "add", Path: path, Value: values[path]}
+	}
+	return j.Apply(ops)
+}
+
+// batch applies operations to root, a copy of the document whose containers are copied on first write.
+// owned caches the containers already copied by their joined key path; containers not in owned may still
+// be shared with the original document and must not be modified.
+type batch struct {
+	root  map[string]interface{}
+	owned map[string]interface{}
+}
+
+// apply executes a single operation. When the parent of the target is an object, the operation is
+// applied to it directly; otherwise it falls back to a full path edit along the copied parents.
+func (b *batch) apply(op Op) error {
+	keys := splitKeyPath(op.Path)
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: empty", ErrInvalidPath)
+	}
+	key := keys[len(keys)-1]
+
+	node, parentPath := b.own(keys[:len(keys)-1])
+	parent, ok := node.(map[string]interface{})
+	if !ok {
+		b.invalidate(parentPath)
+		_, err := editIn(b.root, keys, op.Action == "add", func(old interface{}, exists bool) (interface{}, error) {
+			if op.Action == "add" {
+				return op.Value, nil
+			}
+			if !exists {
+				return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+			}
+			if op.Action == "remove" {
+				return removeValue, nil
+			}
+			return op.Value, nil
+		})
+		return err
+	}
+
+	b.invalidate(joinKey(parentPath, key))
+	if _, exists := parent[key]; !exists && op.Action != "add" {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	if op.Action == "remove" {
+		delete(parent, key)
+	} else {
+		parent[key] = op.Value
+	}
+	return nil
+}
+
+// own returns the container at keys, copying it and every container above it that is not owned yet,
+// along with its key path. Owned containers are cached by key path with array indexes resolved,
+// so "-1" and the index of the last element share a single copy.
+// The walk stops at the first segment that does not resolve to an object or array, returning nil and
+// the key path of that segment; the containers visited up to that point are owned, so editIn may safely
+// modify them.
+func (b *batch) own(keys []string) (interface{}, string) {
+	var node interface{} = b.root
+	path := ""
+	for _, key := range keys {
+		var child interface{}
+		switch current := node.(type) {
+		case map[string]interface{}:
+			path = joinKey(path, key)
+			if owned, ok := b.owned[path]; ok {
+				node = owned
+				continue
+			}
+			value, ok := current[key]
+			if !ok {
+				return nil, path
+			}
+			child = shallowCopy(expandLazy(value))
+			current[key] = child
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err == nil && index == -1 {
+				index = len(current) - 1
+			}
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, joinKey(path, key)
+			}
+			path = joinKey(path, strconv.Itoa(index))
+			if owned, ok := b.owned[path]; ok {
+				node = owned
+				continue
+			}
+			child = shallowCopy(expandLazy(current[index]))
+			current[index] = child
+		}
+
+		switch child.(type) {
+		case map[string]interface{}, []interface{}:
+			b.owned[path] = child
+			node = child
+		default:
+			return nil, path
+		}
+	}
+	return node, path
+}
+
+// invalidate drops owned containers at or below keyPath, which may have been replaced.
+// They are copied again by the next own call that reaches them.
+func (b *batch) invalidate(keyPath string) {
+	for k := range b.owned {
+		if keyPath == "" || k == keyPath || strings.HasPrefix(k, keyPath+".") {
+			delete(b.owned, k)
+		}
+	}
+}
+
+// shallowCopy duplicates the top level of a map or slice; any other value is returned as is.
+func shallowCopy(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if value == nil {
+			return value
+		}
+		m := make(map[string]interface{}, len(value))
+		for k, child := range value {
+			m[k] = child
+		}
+		return m
+	case []interface{}:
+		if value == nil {
+			return value
+		}
+		return append([]interface{}(nil), value...)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,117 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	err := j.Apply([]Op{
+		{Action: "set", Path: "testData.nested.number", Value: 16.0},
+		{Action: "add", Path: "testData.nested.extra", Value: "x"},
+		{Action: "add", Path: "testData.nested", Value: map[string]interface{}{"replaced": true}},
+		{Action: "add", Path: "testData.nested.after", Value: 1.0},
+		{Action: "remove", Path: "testData.s2[0]"},
+		{Action: "set", Path: "testData.s2[0].name", Value: "robert"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"after":1,"replaced":true}`
+	if m, _ := j.FindMap("testData.nested"); m == nil || mustPrint(m) != want {
+		t.Errorf("nested: got %v, want %s", m, want)
+	}
+	if v := j.FindStringOr("testData.s2.0.name", ""); v != "robert" {
+		t.Errorf("array edit: got %q", v)
+	}
+
+	before := j.Print()
+	err = j.Apply([]Op{
+		{Action: "set", Path: "testData.number", Value: 1.0},
+		{Action: "remove", Path: "testData.missing"},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if j.Print() != before {
+		t.Error("failed batch modified the document")
+	}
+}
+
+func TestApplyCopiesTouchedPaths(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	old := j.m
+	before := j.Print()
+	untouched, _ := lookupIn(old, []string{"testData", "s2", "0"})
+
+	err := j.Apply([]Op{
+		{Action: "set", Path: "testData.nested.number", Value: 16.0},
+		{Action: "remove", Path: "testData.sliced[0]"},
+		{Action: "add", Path: "testData.sliced[-1]", Value: 6.0},
+		{Action: "set", Path: "testData.s2[-1].name", Value: "cynthia"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if snapshot, _ := NewJsonMapObject(old); snapshot.Print() != before {
+		t.Errorf("previous document was modified: %s", snapshot.Print())
+	}
+	if v, _ := lookupIn(j.m, []string{"testData", "s2", "0"}); reflect.ValueOf(v).Pointer() != reflect.ValueOf(untouched).Pointer() {
+		t.Error("untouched subtree was copied")
+	}
+	if got, _ := j.FindIntSlice("testData.sliced"); len(got) != 5 || got[0] != 2 || got[4] != 6 {
+		t.Errorf("testData.sliced = %v", got)
+	}
+	if v := j.FindStringOr("testData.s2[2].name", ""); v != "cynthia" {
+		t.Errorf("testData.s2[2].name: got %q", v)
+	}
+}
+
+func TestApplyNegativeIndex(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"arr": [{"a": 0}, {"b": 0}]}`)
+
+	err := j.Apply([]Op{
+		{Action: "add", Path: "arr[-1].x", Value: 1.0},
+		{Action: "add", Path: "arr[1].y", Value: 2.0},
+		{Action: "add", Path: "arr[-1].z", Value: 3.0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"arr":[{"a":0},{"b":0,"x":1,"y":2,"z":3}]}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func mustPrint(m map[string]interface{}) string {
+	j, _ := NewJsonMapObject(m)
+	return j.Print()
+}
+
+func TestSetMany(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	err := j.SetMany(map[string]interface{}{
+		"testData.string":     "override",
+		"testData.s2[2].name": "cynthia",
+		"server.port":         8080.0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := j.FindStringOr("testData.string", ""); v != "override" {
+		t.Errorf("testData.string: got %q", v)
+	}
+	if v := j.FindStringOr("testData.s2.2.name", ""); v != "cynthia" {
+		t.Errorf("testData.s2[2].name: got %q", v)
+	}
+	if v := j.FindIntOr("server.port", 0); v != 8080 {
+		t.Errorf("server.port: got %d", v)
+	}
+}
@@ -51,3 +51,22 @@ func BenchmarkRemoveSubmap(b *testing.B) {
 		_ = j.Remove("child.1.map.child.1.subslice.1")
 	}
 }
+
+func BenchmarkFindPath(b *testing.B) {
+	j, _ := NewJsonMapStr(test_json_string)
+	p := MustCompilePath("child.1.map.child.1.subslice.1.id")
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = j.FindPath(p)
+	}
+}
+
+func BenchmarkFindNested(b *testing.B) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = j.FindStringOr("testData.s2[1].name", "")
+	}
+}
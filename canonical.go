@@ -0,0 +1,156 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Canonicalize returns the document serialized according to the JSON Canonicalization Scheme (RFC 8785),
+// so that semantically equal documents yield identical bytes that can be hashed or signed reproducibly:
+// no whitespace, object keys sorted by their UTF-16 code units, numbers in the shortest form that
+// round-trips through an IEEE 754 double (as in ECMAScript), and strings escaped minimally.
+// Returns an error if the document contains NaN or infinite numbers, strings that are not valid UTF-8,
+// or values that cannot be represented as JSON.
+func (j *JsonMapper) Canonicalize() ([]byte, error) {
+	j.materialize()
+	value, err := normalizeValue(j.m)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical appends the canonical form of a normalized value to buf.
+func writeCanonical(buf *bytes.Buffer, v interface{}) error {
+	switch value := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case string:
+		return writeCanonicalString(buf, value)
+	case float64:
+		s, err := canonicalNumber(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return fmt.Errorf("invalid number: %s", value)
+		}
+		return writeCanonical(buf, f)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, child := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(a, b int) bool { return lessUTF16(keys[a], keys[b]) })
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalString(buf, k); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, value[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unsupported value of type %T", v)
+	}
+	return nil
+}
+
+// canonicalNumber formats f like ECMAScript's Number.prototype.toString, as required by RFC 8785.
+func canonicalNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("number %v cannot be represented in JSON", f)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	// Exponential form: Go writes at least two exponent digits ("1e-07"), ECMAScript as few as possible.
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	i := strings.IndexByte(s, 'e')
+	mantissa, sign, exponent := s[:i], s[i+1], strings.TrimLeft(s[i+2:], "0")
+	return mantissa + "e" + string(sign) + exponent, nil
+}
+
+// writeCanonicalString writes s as a JSON string, escaping only quotes, backslashes and control characters.
+func writeCanonicalString(buf *bytes.Buffer, s string) error {
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("string is not valid UTF-8: %q", s)
+	}
+
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			buf.WriteString(`\"`)
+		case c == '\\':
+			buf.WriteString(`\\`)
+		case c == '\b':
+			buf.WriteString(`\b`)
+		case c == '\f':
+			buf.WriteString(`\f`)
+		case c == '\n':
+			buf.WriteString(`\n`)
+		case c == '\r':
+			buf.WriteString(`\r`)
+		case c == '\t':
+			buf.WriteString(`\t`)
+		case c < 0x20:
+			fmt.Fprintf(buf, `\u%04x`, c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+// lessUTF16 orders strings by their UTF-16 code units, as RFC 8785 requires for object keys.
+func lessUTF16(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
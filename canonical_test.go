@@ -0,0 +1,66 @@
+package jsonmapper_v2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCanonicalNumber(t *testing.T) {
+	tests := map[float64]string{
+		0:                       "0",
+		math.Copysign(0, -1):    "0",
+		1:                       "1",
+		-1.5:                    "-1.5",
+		1e21:                    "1e+21",
+		1e20:                    "100000000000000000000",
+		1e-7:                    "1e-7",
+		0.000001:                "0.000001",
+		4.50:                    "4.5",
+		2e-3:                    "0.002",
+		333333333.33333329:      "333333333.3333333",
+		1.7976931348623157e308:  "1.7976931348623157e+308",
+		5e-324:                  "5e-324",
+		-9007199254740992:       "-9007199254740992",
+		295147905179352830000.0: "295147905179352830000",
+	}
+	for f, want := range tests {
+		if got, err := canonicalNumber(f); err != nil || got != want {
+			t.Errorf("canonicalNumber(%v) = %q, %v, want %q", f, got, err, want)
+		}
+	}
+	if _, err := canonicalNumber(math.NaN()); err == nil {
+		t.Error("expected error for NaN")
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	// Example from RFC 8785, section 3.2.2.
+	j, err := NewJsonMapStr(`{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "€$\u000F\u000aA'B\"\\\\\"\/",
+		"literals": [null, true, false]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := j.Canonicalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	// Keys are sorted by UTF-16 code units, so U+1F600 (a surrogate pair) sorts before U+FB33.
+	k, _ := NewJsonMapStr(`{"דּ": 1, "😀": 2, "<&>": 3}`)
+	got, _ = k.Canonicalize()
+	if want := "{\"<&>\":3,\"\U0001F600\":2,\"דּ\":1}"; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	n, _ := NewJsonMapStr(`{"a": 10.0, "b": 1e2}`, UseNumber())
+	if got, _ := n.Canonicalize(); string(got) != `{"a":10,"b":100}` {
+		t.Errorf("got %s", got)
+	}
+}
@@ -0,0 +1,80 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// NewJsonMapCBOR initializes a new JsonMapper instance from CBOR data, so IoT and COSE payloads
+// can be loaded and edited by path. Maps become objects with keys formatted as strings (COSE's integer
+// labels become "1", "-7", ...), integers and floats become float64, byte strings become strings and
+// timestamps become RFC 3339 strings. Tagged values are replaced by their content.
+// Returns an error if decoding fails or the top-level value is not a map.
+func NewJsonMapCBOR(data []byte) (*JsonMapper, error) {
+	var v interface{}
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	m, err := normalizeDocument(untagCBOR(v), "CBOR")
+	if err != nil {
+		return nil, err
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// MarshalCBOR returns the current structure encoded as CBOR using the core deterministic encoding:
+// map keys are sorted and numbers without a fractional part are encoded as integers.
+func (j *JsonMapper) MarshalCBOR() ([]byte, error) {
+	j.materialize()
+	em, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		return nil, err
+	}
+	return em.Marshal(cborValue(j.m))
+}
+
+// untagCBOR replaces tagged values within a decoded CBOR value by their content.
+func untagCBOR(v interface{}) interface{} {
+	switch value := v.(type) {
+	case cbor.Tag:
+		return untagCBOR(value.Content)
+	case map[interface{}]interface{}:
+		for k, child := range value {
+			value[k] = untagCBOR(child)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = untagCBOR(child)
+		}
+	}
+	return v
+}
+
+// cborValue copies a value for the CBOR encoder, converting whole float64 numbers and json.Number values
+// to int64 where possible.
+func cborValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for k, child := range value {
+			out[k] = cborValue(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, child := range value {
+			out[i] = cborValue(child)
+		}
+		return out
+	case float64:
+		if n, ok := wholeNumber(value); ok {
+			return n
+		}
+		return value
+	case json.Number:
+		return nativeNumber(value)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,43 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestCBOR(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	data, err := j.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := NewJsonMapCBOR(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Print() != j.Print() {
+		t.Errorf("round trip: got %s, want %s", decoded.Print(), j.Print())
+	}
+
+	// A COSE-style header map with integer labels inside a tagged array.
+	cose, _ := cbor.Marshal(map[string]interface{}{
+		"msg": cbor.Tag{Number: 18, Content: []interface{}{map[int]interface{}{1: -7}, []byte("payload")}},
+	})
+	decoded, err = NewJsonMapCBOR(cose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alg, err := decoded.FindInt("msg[0].1"); err != nil || alg != -7 {
+		t.Errorf("alg = %v, %v", alg, err)
+	}
+	if payload, _ := decoded.FindString("msg[1]"); payload != "payload" {
+		t.Errorf("payload = %q", payload)
+	}
+
+	scalar, _ := cbor.Marshal("text")
+	if _, err := NewJsonMapCBOR(scalar); err == nil {
+		t.Error("expected error for a non-map document")
+	}
+}
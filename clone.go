@@ -0,0 +1,43 @@
+package jsonmapper_v2
+
+// Clone returns a deep copy of the JsonMapper.
+// Every nested map and slice is duplicated, so modifications made through the clone
+// never affect the original instance and vice versa. A fallback mapper set with WithFallback is shared, not copied.
+func (j *JsonMapper) Clone() *JsonMapper {
+	m, _ := deepCopy(j.m).(map[string]interface{})
+	return &JsonMapper{
+		m:            m,
+		lazy:         j.lazy,
+		coerce:       j.coerce,
+		fallback:     j.fallback,
+		printOptions: j.printOptions,
+		cache:        j.cache.empty(),
+	}
+}
+
+// deepCopy recursively duplicates maps and slices found in v.
+// Scalar values (strings, numbers, booleans and nil) are immutable and returned as is.
+func deepCopy(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if value == nil {
+			return value
+		}
+		m := make(map[string]interface{}, len(value))
+		for k, child := range value {
+			m[k] = deepCopy(child)
+		}
+		return m
+	case []interface{}:
+		if value == nil {
+			return value
+		}
+		s := make([]interface{}, len(value))
+		for i, child := range value {
+			s[i] = deepCopy(child)
+		}
+		return s
+	default:
+		return value
+	}
+}
@@ -0,0 +1,47 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+)
+
+var test_nested_json_string string = `
+{
+	"testData": {
+		"number": 25,
+		"string": "hello",
+		"bool": true,
+		"nested": {
+			"number": 15,
+			"string": "world"
+		},
+		"sliced": [1, 2, 3, 4, 5],
+		"s2": [
+			{"id": 1, "name": "alice"},
+			{"id": 2, "name": "bob"},
+			{"id": 3, "name": "cindy"}
+		]
+	}
+}
+`
+
+func TestClone(t *testing.T) {
+	j, err := NewJsonMapStr(test_nested_json_string)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := j.Clone()
+	if err := c.Add("testData.nested.number", 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Remove("testData.sliced[0]"); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := j.FindIntOr("testData.nested.number", -1); v != 15 {
+		t.Errorf("original map was modified through clone: got %d", v)
+	}
+	if s, _ := j.FindSlice("testData.sliced"); len(s) != 5 || s[0] != 1.0 {
+		t.Errorf("original slice was modified through clone: got %v", s)
+	}
+}
@@ -0,0 +1,73 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// EnableCoercion makes the typed finders convert between representations instead of rejecting them,
+// for documents produced by systems that stringify everything:
+//   - numeric finders such as FindInt, FindInt64, FindUint64 and FindFloat accept numeric strings like "25";
+//   - FindBool accepts the strings accepted by strconv.ParseBool ("true", "false", "1", "0", ...)
+//     and the numbers 1 and 0;
+//   - FindString accepts numbers and booleans and returns them formatted as in JSON.
+//
+// The slice finders apply the same conversions to each element. Coercion is disabled by default.
+func (j *JsonMapper) EnableCoercion() {
+	j.coerce = true
+}
+
+// DisableCoercion restores the default strict behavior of the typed finders.
+func (j *JsonMapper) DisableCoercion() {
+	j.coerce = false
+}
+
+// coerceNumber converts a numeric string into a json.Number if coercion is enabled,
+// so the numeric finders treat it like a number decoded with UseNumber. Other values are returned unchanged.
+func (j *JsonMapper) coerceNumber(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !j.coerce || !ok {
+		return v
+	}
+	s = strings.TrimSpace(s)
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) || !json.Valid([]byte(s)) {
+		return v
+	}
+	return json.Number(s)
+}
+
+// coerceBool converts boolean strings and the numbers 0 and 1 into a bool if coercion is enabled.
+// Other values are returned unchanged.
+func (j *JsonMapper) coerceBool(v interface{}) interface{} {
+	if !j.coerce {
+		return v
+	}
+	if s, ok := v.(string); ok {
+		if b, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+			return b
+		}
+		return v
+	}
+	if f, ok := toFloat64(v); ok && (f == 0 || f == 1) {
+		return f == 1
+	}
+	return v
+}
+
+// coerceString formats numbers and booleans as strings if coercion is enabled.
+// Other values are returned unchanged.
+func (j *JsonMapper) coerceString(v interface{}) interface{} {
+	if !j.coerce {
+		return v
+	}
+	switch value := v.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case json.Number:
+		return string(value)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return v
+}
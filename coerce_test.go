@@ -0,0 +1,51 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestCoercion(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"port": "8080", "ratio": " 0.5 ", "debug": "true", "flag": 1, "two": 2,
+		"id": 42, "name": "x", "ids": ["1", 2], "inf": "Inf"}`)
+
+	if _, err := j.FindInt("port"); err == nil {
+		t.Error("strict mode should reject numeric strings")
+	}
+	if _, err := j.FindBool("debug"); err == nil {
+		t.Error("strict mode should reject boolean strings")
+	}
+
+	j.EnableCoercion()
+	if got, err := j.FindInt("port"); err != nil || got != 8080 {
+		t.Errorf("FindInt = %d, %v", got, err)
+	}
+	if got, err := j.FindFloat("ratio"); err != nil || got != 0.5 {
+		t.Errorf("FindFloat = %v, %v", got, err)
+	}
+	if got, err := j.FindBool("debug"); err != nil || !got {
+		t.Errorf("FindBool(debug) = %v, %v", got, err)
+	}
+	if got, err := j.FindBool("flag"); err != nil || !got {
+		t.Errorf("FindBool(flag) = %v, %v", got, err)
+	}
+	if got, err := j.FindString("id"); err != nil || got != "42" {
+		t.Errorf("FindString = %q, %v", got, err)
+	}
+	if got, err := j.FindIntSlice("ids"); err != nil || len(got) != 2 || got[0] != 1 {
+		t.Errorf("FindIntSlice = %v, %v", got, err)
+	}
+	for _, k := range []string{"name", "inf"} {
+		if _, err := j.FindInt(k); err == nil {
+			t.Errorf("FindInt(%s): expected error", k)
+		}
+	}
+	if _, err := j.FindBool("two"); err == nil {
+		t.Error("FindBool(two): expected error")
+	}
+	if !j.Clone().coerce {
+		t.Error("Clone should keep coercion enabled")
+	}
+
+	j.DisableCoercion()
+	if _, err := j.FindInt("port"); err == nil {
+		t.Error("expected error after DisableCoercion")
+	}
+}
@@ -0,0 +1,95 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ANSI escape sequences used by PrettyPrintColor.
+const (
+	colorReset  = "\x1b[0m"
+	colorKey    = "\x1b[34;1m"
+	colorString = "\x1b[32m"
+	colorNumber = "\x1b[36m"
+	colorBool   = "\x1b[33m"
+	colorNull   = "\x1b[90m"
+)
+
+// PrettyPrintColor returns the document formatted like PrettyPrint, with ANSI color codes distinguishing
+// object keys (bold blue), strings (green), numbers (cyan), booleans (yellow) and null (gray),
+// for debugging large documents in a terminal. The output is not valid JSON.
+func (j *JsonMapper) PrettyPrintColor() string {
+	data, err := j.marshalJSON(j.printOptions, true)
+	if err != nil {
+		return ""
+	}
+	return string(colorizeJSON(data))
+}
+
+// colorizeJSON wraps the tokens of the JSON text data in ANSI color codes.
+func colorizeJSON(data []byte) []byte {
+	var out bytes.Buffer
+	out.Grow(len(data) * 2)
+
+	for i := 0; i < len(data); {
+		c := data[i]
+		var end int
+		var color string
+		switch {
+		case c == '"':
+			end = stringEnd(data, i)
+			color = colorString
+			if next := skipSpace(data, end); next < len(data) && data[next] == ':' {
+				color = colorKey
+			}
+		case c == '-' || (c >= '0' && c <= '9'):
+			end = tokenEnd(data, i)
+			color = colorNumber
+		case c == 't' || c == 'f':
+			end = tokenEnd(data, i)
+			color = colorBool
+		case c == 'n':
+			end = tokenEnd(data, i)
+			color = colorNull
+		default:
+			out.WriteByte(c)
+			i++
+			continue
+		}
+		out.WriteString(color)
+		out.Write(data[i:end])
+		out.WriteString(colorReset)
+		i = end
+	}
+	return out.Bytes()
+}
+
+// stringEnd returns the index just past the closing quote of the string starting at data[start].
+func stringEnd(data []byte, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// tokenEnd returns the index just past the number or literal starting at data[start].
+func tokenEnd(data []byte, start int) int {
+	i := start
+	for i < len(data) && strings.IndexByte("0123456789+-.eEtruefalsn", data[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+// skipSpace returns the index of the first non-whitespace byte at or after i.
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,31 @@
+package jsonmapper_v2
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPrettyPrintColor(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"name": "a \"quoted\": b", "n": -1.5e3, "ok": true, "off": false, "none": null, "list": [1, "x"]}`)
+
+	got := j.PrettyPrintColor()
+	for _, want := range []string{
+		colorKey + `"name"` + colorReset + ": " + colorString + `"a \"quoted\": b"` + colorReset,
+		colorKey + `"n"` + colorReset + ": " + colorNumber + "-1500" + colorReset,
+		colorBool + "true" + colorReset,
+		colorBool + "false" + colorReset,
+		colorNull + "null" + colorReset,
+		colorNumber + "1" + colorReset + ",",
+		colorString + `"x"` + colorReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output %q does not contain %q", got, want)
+		}
+	}
+
+	plain := regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(got, "")
+	if plain != j.PrettyPrint() {
+		t.Errorf("stripping colors gives %q, want %q", plain, j.PrettyPrint())
+	}
+}
@@ -0,0 +1,53 @@
+package jsonmapper_v2
+
+// Compact prunes the document recursively, removing null values if removeNulls is set, empty objects if
+// removeEmptyObjects is set and empty arrays if removeEmptyArrays is set. Both object keys and array
+// elements are removed. Pruning works bottom-up, so a container that becomes empty because all of its
+// children were removed is removed as well. The root object itself is never removed.
+// The pruning is recorded as a single mutation.
+func (j *JsonMapper) Compact(removeNulls, removeEmptyObjects, removeEmptyArrays bool) error {
+	j.materialize()
+	return j.mutate("compact", "", nil, func() error {
+		prune := func(v interface{}) bool {
+			switch value := v.(type) {
+			case nil:
+				return removeNulls
+			case map[string]interface{}:
+				return removeEmptyObjects && len(value) == 0
+			case []interface{}:
+				return removeEmptyArrays && len(value) == 0
+			}
+			return false
+		}
+		m, _ := deepCopy(j.m).(map[string]interface{})
+		compactValue(m, prune)
+		j.m = m
+		return nil
+	})
+}
+
+// compactValue removes the children of v for which prune returns true, after compacting them,
+// and returns the compacted value. Maps are updated in place, while slices are rebuilt.
+func compactValue(v interface{}, prune func(interface{}) bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, child := range value {
+			child = compactValue(child, prune)
+			if prune(child) {
+				delete(value, k)
+			} else {
+				value[k] = child
+			}
+		}
+	case []interface{}:
+		compacted := make([]interface{}, 0, len(value))
+		for _, child := range value {
+			child = compactValue(child, prune)
+			if !prune(child) {
+				compacted = append(compacted, child)
+			}
+		}
+		return compacted
+	}
+	return v
+}
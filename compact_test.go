@@ -0,0 +1,26 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestCompact(t *testing.T) {
+	const doc = `{"a": null, "b": {}, "c": [], "d": {"e": null, "f": [null, {}]}, "g": [1, null, []], "h": 0, "i": ""}`
+
+	tests := []struct {
+		nulls, objects, arrays bool
+		want                   string
+	}{
+		{true, false, false, `{"b":{},"c":[],"d":{"f":[{}]},"g":[1,[]],"h":0,"i":""}`},
+		{false, true, false, `{"a":null,"c":[],"d":{"e":null,"f":[null]},"g":[1,null,[]],"h":0,"i":""}`},
+		{false, false, true, `{"a":null,"b":{},"d":{"e":null,"f":[null,{}]},"g":[1,null],"h":0,"i":""}`},
+		{true, true, true, `{"g":[1],"h":0,"i":""}`},
+	}
+	for _, tt := range tests {
+		j, _ := NewJsonMapStr(doc)
+		if err := j.Compact(tt.nulls, tt.objects, tt.arrays); err != nil {
+			t.Fatal(err)
+		}
+		if got := j.Print(); got != tt.want {
+			t.Errorf("Compact(%v, %v, %v) = %s, want %s", tt.nulls, tt.objects, tt.arrays, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package jsonmapper_v2
+
+import "fmt"
+
+// Path is a key path that has been parsed once by CompilePath, so that lookups in hot loops
+// do not parse the same string on every call. A Path is immutable and safe for concurrent use,
+// and can be used with any JsonMapper.
+type Path struct {
+	raw  string
+	keys []string
+}
+
+// CompilePath parses keyPath, using the same syntax as Find, into a Path for use with FindPath.
+// An empty keyPath refers to the root object.
+// Returns an error wrapping ErrInvalidPath if keyPath contains an empty segment, such as "a..b"
+// or a trailing dot, which is almost always a typo.
+func CompilePath(keyPath string) (*Path, error) {
+	keys := splitKeyPath(keyPath)
+	for i, key := range keys {
+		if key == "" {
+			return nil, newPathError(keyPath, key, i, nil, fmt.Errorf("%w: empty segment", ErrInvalidPath))
+		}
+	}
+	return &Path{raw: keyPath, keys: keys}, nil
+}
+
+// MustCompilePath is like CompilePath but panics if the path is invalid.
+// It is intended for initializing package-level Path variables.
+func MustCompilePath(keyPath string) *Path {
+	p, err := CompilePath(keyPath)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the key path the Path was compiled from.
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Segments returns the object keys and array indexes of the path in order, with bracket indexes
+// in dot notation, so "a.b[0]" yields ["a", "b", "0"].
+func (p *Path) Segments() []string {
+	return append([]string(nil), p.keys...)
+}
+
+// FindPath is like Find but resolves a compiled Path, skipping the parsing of the key path.
+// If the path cannot be resolved and a fallback mapper is set (see WithFallback), the fallback is consulted instead.
+func (j *JsonMapper) FindPath(p *Path) (interface{}, error) {
+	value, err := j.findKeys(p.raw, p.keys)
+	if err != nil && j.fallback != nil {
+		if fallbackValue, fallbackErr := j.fallback.FindPath(p); fallbackErr == nil {
+			return fallbackValue, nil
+		}
+	}
+	return value, err
+}
@@ -0,0 +1,59 @@
+package jsonmapper_v2
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompilePath(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	p, err := CompilePath("testData.s2[1].name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != "testData.s2[1].name" {
+		t.Errorf("String() = %q", p.String())
+	}
+	if got := p.Segments(); !reflect.DeepEqual(got, []string{"testData", "s2", "1", "name"}) {
+		t.Errorf("Segments() = %v", got)
+	}
+
+	want, _ := j.Find("testData.s2[1].name")
+	if got, err := j.FindPath(p); err != nil || got != want {
+		t.Errorf("FindPath() = %v, %v, want %v", got, err, want)
+	}
+
+	root, _ := CompilePath("")
+	if got, err := j.FindPath(root); err != nil || got == nil {
+		t.Errorf("FindPath(root) = %v, %v", got, err)
+	}
+
+	missing := MustCompilePath("testData.nested.nmber")
+	var pathErr *PathError
+	if _, err := j.FindPath(missing); !errors.As(err, &pathErr) || pathErr.FullPath != "testData.nested.nmber" || pathErr.SegmentIndex != 2 {
+		t.Errorf("FindPath(missing) error = %v", err)
+	}
+
+	defaults, _ := NewJsonMapStr(`{"testData": {"nested": {"nmber": 1}}}`)
+	_ = j.WithFallback(defaults)
+	if got, err := j.FindPath(missing); err != nil || got != float64(1) {
+		t.Errorf("FindPath() with fallback = %v, %v", got, err)
+	}
+}
+
+func TestCompilePathInvalid(t *testing.T) {
+	for _, keyPath := range []string{"a..b", ".a", "a."} {
+		if _, err := CompilePath(keyPath); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("CompilePath(%q) error = %v", keyPath, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustCompilePath did not panic")
+		}
+	}()
+	MustCompilePath("a..b")
+}
@@ -1,16 +1,34 @@
 package jsonmapper_v2
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // FindAllWithCondition searches through the JSON structure starting from the given keyPath
 // and returns all paths that satisfy the specified conditions. The conditions parameter
 // should be a map or nested maps with logical and comparison operators as keys.
-// Supported logical operators include "and", "or", "xor", and "nor".
+// Supported logical operators include "and", "or", "xor", and "nor", which can be nested to any depth,
+// and the unary "not", which negates a single nested condition.
 // Supported comparison operators include "eq" (equal), "neq" (not equal),
-// "lt" (less than), "lte" (less than or equal), "gt" (greater than), and "gte" (greater than or equal).
+// "lt" (less than), "lte" (less than or equal), "gt" (greater than), "gte" (greater than or equal),
+// "in" (equal to any element of a slice of candidates), "nin" (equal to none of them),
+// "between" (numeric range, see Range), "regex" (string matching a regular expression),
+// "startsWith" and "endsWith" (string prefix and suffix),
+// "contains" (substring of a string or element of an array), and the case-insensitive "ieq" and "icontains".
+// The "exists" operator matches objects that have (or, given a map of field flags, lack) certain fields.
+// The "type" operator matches values of a JSON kind ("object", "array", "string", "number", "bool" or "null").
+// The "key" operator applies nested conditions to key names instead of values, e.g. {"key": {"regex": "^child"}}.
+// Any other key is treated as a field name, turning the condition into a field-scoped query over objects,
+// e.g. {"name": {"eq": "alice"}, "id": {"gt": 1}} returns the paths of objects whose fields satisfy
+// every nested condition; a plain operand such as {"name": "alice"} compares for equality.
+// When "contains", "icontains", "exists", "type", "key" or a field condition is used, objects and arrays
+// are evaluated as values as well and their own paths may be returned.
 // The function recursively traverses the JSON structure, evaluating each value against the conditions.
 // If a value satisfies the conditions, its path is added to the results.
 //
@@ -19,6 +37,8 @@ import (
 //     If empty, the search starts from the root of the JSON structure.
 //   - conditions: A map or nested maps specifying the conditions that values must satisfy.
 //     The keys are logical or comparison operators, and the values are the operands.
+//   - opts: Optional QueryOption values (Limit, Offset, SortByPath, SortByValue) to sort and paginate the results.
+//     Without options the results are returned in traversal order, which is not deterministic for objects.
 //
 // Returns:
 // - A slice of strings containing the paths of all values that satisfy the conditions.
@@ -28,40 +48,207 @@ import (
 // To find all paths where the "id" is greater than 2, you could use:
 // conditions := map[string]interface{}{"gt": 2}
 // paths, err := jm.FindAllWithCondition("testData.s2", conditions)
-func (j *JsonMapper) FindAllWithCondition(keyPath string, conditions interface{}) ([]string, error) {
+func (j *JsonMapper) FindAllWithCondition(keyPath string, conditions interface{}, opts ...QueryOption) ([]string, error) {
+	matches, err := j.findAllMatches(keyPath, conditions, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var results []string
+	for _, match := range matches {
+		results = append(results, match.Path)
+	}
+	return results, nil
+}
+
+// Match describes a value found by FindAllWithConditionValues.
+// Path is the keyPath of the value, Value is the value itself,
+// and ParentPath is the keyPath of the object or array containing it (empty for children of the root).
+type Match struct {
+	Path       string
+	Value      interface{}
+	ParentPath string
+}
+
+// FindAllWithConditionValues works like FindAllWithCondition but returns the matching values
+// together with their paths and parent paths, so callers don't need to Find every returned path again.
+// The values are not copied; modifying a returned object or array modifies the document.
+func (j *JsonMapper) FindAllWithConditionValues(keyPath string, conditions interface{}, opts ...QueryOption) ([]Match, error) {
+	return j.findAllMatches(keyPath, conditions, opts)
+}
+
+// RemoveAllWithCondition deletes every value below keyPath that satisfies the conditions in a single traversal
+// and returns the number of values removed. Matching object entries are deleted and matching array elements
+// are removed with the following elements shifted, e.g. {"eq": nil} strips every null and {"eq": ""} every
+// empty string. Conditions are interpreted as in FindAllWithCondition; the value at keyPath itself is never removed.
+// The document is left unchanged if the conditions are invalid or cannot be evaluated.
+func (j *JsonMapper) RemoveAllWithCondition(keyPath string, conditions interface{}) (int, error) {
+	return j.rewriteMatches("removeAll", keyPath, conditions, func(old interface{}) (interface{}, error) {
+		return removeValue, nil
+	})
+}
+
+// UpdateAllWithCondition replaces every value below keyPath that satisfies the conditions and returns
+// the number of values replaced. The replacement is either a fixed value or a callback of the form
+// func(old interface{}) (interface{}, error) that computes the new value from the old one, e.g.
+// jm.UpdateAllWithCondition("", map[string]interface{}{"regex": `^[^@]+@[^@]+$`}, "[redacted]").
+// Replaced objects and arrays are not searched further. Conditions are interpreted as in FindAllWithCondition;
+// the value at keyPath itself is never replaced. The document is left unchanged if the conditions
+// cannot be evaluated or the callback returns an error.
+func (j *JsonMapper) UpdateAllWithCondition(keyPath string, conditions interface{}, replacement interface{}) (int, error) {
+	fn, ok := replacement.(func(interface{}) (interface{}, error))
+	if !ok {
+		fn = func(old interface{}) (interface{}, error) {
+			return deepCopy(replacement), nil
+		}
+	}
+	return j.rewriteMatches("updateAll", keyPath, conditions, fn)
+}
 
-	var evaluate func(interface{}, string) error
-	evaluate = func(current interface{}, currentPath string) error {
+// rewriteMatches replaces every value below keyPath that satisfies the conditions with the result of replace,
+// deleting it when replace returns removeValue, and applies the result as a single mutation identified by op.
+// It returns the number of values replaced.
+func (j *JsonMapper) rewriteMatches(op, keyPath string, conditions interface{}, replace func(old interface{}) (interface{}, error)) (int, error) {
+	j.materialize()
+	conditions, err := compileConditions(conditions)
+	if err != nil {
+		return 0, err
+	}
+	containers := usesContainerOps(conditions)
+
+	var start interface{} = j.m
+	if keyPath != "" {
+		if start, err = j.Find(keyPath); err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	// matches reports whether the value stored under key satisfies the conditions.
+	matches := func(value interface{}, key string) (bool, error) {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			if !containers {
+				return false, nil
+			}
+			satisfied, err := j.evaluateCondition(value, key, conditions)
+			return err == nil && satisfied, nil
+		default:
+			return j.evaluateCondition(value, key, conditions)
+		}
+	}
+	// rewrite returns the value stored under key with matching values replaced.
+	// Containers are copied rather than modified, so the document is untouched until the result is applied.
+	var rewrite func(current interface{}, key string, root bool) (interface{}, error)
+	rewrite = func(current interface{}, key string, root bool) (interface{}, error) {
+		if !root {
+			matched, err := matches(current, key)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				count++
+				return replace(current)
+			}
+		}
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(v))
+			for k, child := range v {
+				child, err := rewrite(child, k, false)
+				if err != nil {
+					return nil, err
+				}
+				if child != removeValue {
+					out[k] = child
+				}
+			}
+			return out, nil
+		case []interface{}:
+			out := make([]interface{}, 0, len(v))
+			for i, child := range v {
+				child, err := rewrite(child, strconv.Itoa(i), false)
+				if err != nil {
+					return nil, err
+				}
+				if child != removeValue {
+					out = append(out, child)
+				}
+			}
+			return out, nil
+		default:
+			return current, nil
+		}
+	}
+
+	result, err := rewrite(start, "", true)
+	if err != nil || count == 0 {
+		return 0, err
+	}
+
+	err = j.mutate(op, keyPath, result, func() error {
+		if keyPath == "" {
+			j.m = result.(map[string]interface{})
+			return nil
+		}
+		return j.set(keyPath, result)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// findAllMatches implements FindAllWithCondition and FindAllWithConditionValues.
+func (j *JsonMapper) findAllMatches(keyPath string, conditions interface{}, opts []QueryOption) ([]Match, error) {
+	j.materialize()
+	var results []Match
+
+	conditions, err := compileConditions(conditions)
+	if err != nil {
+		return nil, err
+	}
+	containers := usesContainerOps(conditions)
+
+	// evaluateContainer adds an object or array satisfying a container-aware condition.
+	evaluateContainer := func(results *[]Match, current interface{}, currentPath, key, parentPath string) {
+		if !containers {
+			return
+		}
+		if satisfied, err := j.evaluateCondition(current, key, conditions); err == nil && satisfied {
+			*results = append(*results, Match{Path: currentPath, Value: current, ParentPath: parentPath})
+		}
+	}
+
+	// evaluate adds every value within current satisfying the conditions to results.
+	// It only touches results, so separate subtrees can be evaluated concurrently.
+	var evaluate func(*[]Match, interface{}, string, string, string) error
+	evaluate = func(results *[]Match, current interface{}, currentPath, key, parentPath string) error {
 		switch currentType := current.(type) {
 		case map[string]interface{}:
+			evaluateContainer(results, current, currentPath, key, parentPath)
 			for k, v := range currentType {
-				newPath := currentPath
-				if newPath != "" {
-					newPath += "."
-				}
-				newPath += k
-				evaluate(v, newPath)
+				evaluate(results, v, joinKey(currentPath, k), k, currentPath)
 			}
 		case []interface{}:
+			evaluateContainer(results, current, currentPath, key, parentPath)
 			for i, v := range currentType {
-				newPath := fmt.Sprintf("%s[%d]", currentPath, i)
-				evaluate(v, newPath)
+				evaluate(results, v, joinIndex(currentPath, i), strconv.Itoa(i), currentPath)
 			}
 		default:
-			satisfied, err := j.evaluateCondition(current, conditions)
+			satisfied, err := j.evaluateCondition(current, key, conditions)
 			if err != nil {
 				return err
 			}
 			if satisfied {
-				results = append(results, currentPath)
+				*results = append(*results, Match{Path: currentPath, Value: current, ParentPath: parentPath})
 			}
 		}
 		return nil
 	}
 
 	var startValue interface{}
-	var err error
 
 	if keyPath == "" {
 		startValue = j.m // Use the entire map if the keyPath is root
@@ -72,153 +259,681 @@ func (j *JsonMapper) FindAllWithCondition(keyPath string, conditions interface{}
 		}
 	}
 
-	err = evaluate(startValue, keyPath)
+	var startKey string
+	if keys := splitKeyPath(keyPath); len(keys) > 0 {
+		startKey = keys[len(keys)-1]
+	}
+
+	if workers := resolveQueryOptions(opts).workers; workers > 1 {
+		if children := childEntries(startValue, keyPath); len(children) > 0 {
+			// Evaluate the start value itself, then its children concurrently, each into its own slice.
+			evaluateContainer(&results, startValue, keyPath, startKey, parentKeyPath(keyPath))
+			partial := make([][]Match, len(children))
+			tasks := make(chan int)
+			var wg sync.WaitGroup
+			for w := 0; w < workers && w < len(children); w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range tasks {
+						child := children[i]
+						evaluate(&partial[i], child.value, child.path, child.key, keyPath)
+					}
+				}()
+			}
+			for i := range children {
+				tasks <- i
+			}
+			close(tasks)
+			wg.Wait()
+
+			for _, matches := range partial {
+				results = append(results, matches...)
+			}
+			return applyQueryOptions(results, opts), nil
+		}
+	}
+
+	err = evaluate(&results, startValue, keyPath, startKey, parentKeyPath(keyPath))
 	if err != nil {
 		return nil, err
 	}
 
-	return results, nil
+	return applyQueryOptions(results, opts), nil
+}
+
+// childEntry is an immediate child of an object or array, identified by its path and key.
+type childEntry struct {
+	path  string
+	key   string
+	value interface{}
+}
+
+// childEntries lists the immediate children of the object or array value stored at path,
+// or returns nil if value is a scalar.
+func childEntries(value interface{}, path string) []childEntry {
+	var children []childEntry
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			children = append(children, childEntry{path: joinKey(path, k), key: k, value: child})
+		}
+	case []interface{}:
+		for i, child := range v {
+			children = append(children, childEntry{path: joinIndex(path, i), key: strconv.Itoa(i), value: child})
+		}
+	}
+	return children
+}
+
+// matchesCondition reports whether value, or any value nested within it, satisfies the conditions.
+// Objects and arrays themselves are only evaluated when the conditions use a container-aware operation.
+// Like FindAllWithCondition, values that cannot be compared with the conditions (e.g. "gt" on a string)
+// are treated as not matching.
+func (j *JsonMapper) matchesCondition(value interface{}, key string, conditions interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}, []interface{}:
+		if usesContainerOps(conditions) {
+			if satisfied, err := j.evaluateCondition(v, key, conditions); err == nil && satisfied {
+				return true
+			}
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			for k, child := range m {
+				if j.matchesCondition(child, k, conditions) {
+					return true
+				}
+			}
+			return false
+		}
+		for i, child := range v.([]interface{}) {
+			if j.matchesCondition(child, strconv.Itoa(i), conditions) {
+				return true
+			}
+		}
+		return false
+	default:
+		satisfied, err := j.evaluateCondition(v, key, conditions)
+		return err == nil && satisfied
+	}
 }
 
 // evaluateCondition checks if the given value satisfies the specified conditions.
-// The conditions parameter can be a map containing comparison operations
-// or a map of logical operations that contain comparison operations.
-// This function supports handling complex logical expressions using "and", "or", "xor", and "nor" logical operations,
-// and it supports "eq" (equal), "neq" (not equal), "lt" (less than), "lte" (less than or equal),
-// "gt" (greater than), and "gte" (greater than or equal) comparison operations.
+// The conditions parameter is a map whose keys are operators and whose values are their operands.
+// Comparison operators are evaluated by checkCondition. Logical operators ("and", "or", "xor", "nor",
+// case-insensitive) take a list of nested conditions, each of which may itself contain logical operators,
+// so expressions can be nested to any depth, e.g.
+// {"or": [{"and": [{"gt": 10}, {"lt": 20}]}, {"eq": "hello"}]}.
+// The unary "not" operator takes a single nested condition and negates it, e.g. {"not": {"eq": "hello"}}.
+// Keys that are not operators name fields of an object value and are evaluated by evaluateField.
+// When a map holds several operators, all of them must be satisfied.
+// Both map[string]interface{} and the typed map[string][]map[string]interface{} forms are accepted.
 //
 // Parameters:
 //   - value: The value to be evaluated against the conditions.
+//   - key: The object key or stringified array index under which the value is stored, used by "key" conditions.
 //   - conditions: A map or nested maps specifying the conditions. The keys represent the operators,
 //     and the values represent the operands or further nested conditions.
 //
 // Returns:
 // - A boolean indicating whether the value satisfies the conditions.
 // - An error if an unsupported operation is encountered or if there's an issue evaluating the conditions.
-func (j *JsonMapper) evaluateCondition(value interface{}, conditions interface{}) (bool, error) {
+func (j *JsonMapper) evaluateCondition(value interface{}, key string, conditions interface{}) (bool, error) {
 	switch cond := conditions.(type) {
 	case map[string]interface{}:
-		for op, conditionValue := range cond {
-			return j.checkCondition(value, op, conditionValue)
+		if len(cond) == 0 {
+			return false, fmt.Errorf("no valid condition found")
 		}
+		for _, op := range sortedKeys(cond) {
+			var satisfied bool
+			var err error
+			switch {
+			case isNotOp(op):
+				satisfied, err = j.evaluateCondition(value, key, cond[op])
+				satisfied = !satisfied
+			case isLogicalOp(op):
+				satisfied, err = j.evaluateLogical(value, key, op, cond[op])
+			case comparisonOps[op]:
+				satisfied, err = j.checkCondition(value, key, op, cond[op])
+			default:
+				satisfied, err = j.evaluateField(value, op, cond[op])
+			}
+			if err != nil || !satisfied {
+				return false, err
+			}
+		}
+		return true, nil
 	case map[string][]map[string]interface{}:
+		if len(cond) == 0 {
+			return false, fmt.Errorf("no valid condition found")
+		}
 		for logicalOp, subConditions := range cond {
-			switch logicalOp {
-			case "and", "AND":
-				for _, conditionMap := range subConditions {
-					for op, conditionValue := range conditionMap {
-						satisfied, err := j.checkCondition(value, op, conditionValue)
-						if err != nil || !satisfied {
-							return false, err
-						}
-					}
-				}
-				return true, nil
-			case "or", "OR":
-				satisfiedAny := false
-				for _, conditionMap := range subConditions {
-					for op, conditionValue := range conditionMap {
-						satisfied, err := j.checkCondition(value, op, conditionValue)
-						if err != nil {
-							return false, err
-						}
-						if satisfied {
-							satisfiedAny = true
-							break
-						}
-					}
-					if satisfiedAny {
-						break
-					}
-				}
-				return satisfiedAny, nil
-			case "xor", "XOR":
-				satisfiedCount := 0
-				for _, conditionMap := range subConditions {
-					for op, conditionValue := range conditionMap {
-						satisfied, err := j.checkCondition(value, op, conditionValue)
-						if err != nil {
-							return false, err
-						}
-						if satisfied {
-							satisfiedCount++
-						}
-					}
-				}
-				return satisfiedCount == 1, nil
-			case "nor", "NOR":
-				for _, conditionMap := range subConditions {
-					for op, conditionValue := range conditionMap {
-						satisfied, err := j.checkCondition(value, op, conditionValue)
-						if err != nil {
-							return false, err
-						}
-						if satisfied {
-							return false, nil
-						}
-					}
-				}
-				return true, nil
-			default:
+			if !isLogicalOp(logicalOp) {
 				return false, fmt.Errorf("unsupported logical operation: %s", logicalOp)
 			}
+			satisfied, err := j.evaluateLogical(value, key, logicalOp, subConditions)
+			if err != nil || !satisfied {
+				return false, err
+			}
 		}
+		return true, nil
 	default:
 		return false, fmt.Errorf("invalid conditions format")
 	}
-	return false, fmt.Errorf("no valid condition found")
+}
+
+// evaluateLogical applies the logical operator op to the list of nested conditions given as operand.
+// "and" requires all nested conditions to be satisfied, "or" at least one, "xor" exactly one and "nor" none.
+func (j *JsonMapper) evaluateLogical(value interface{}, key string, op string, operand interface{}) (bool, error) {
+	subConditions, ok := conditionList(operand)
+	if !ok {
+		return false, fmt.Errorf("logical operation %s requires a list of conditions", op)
+	}
+
+	satisfiedCount := 0
+	for _, sub := range subConditions {
+		satisfied, err := j.evaluateCondition(value, key, sub)
+		if err != nil {
+			return false, err
+		}
+		if satisfied {
+			satisfiedCount++
+		}
+
+		switch strings.ToLower(op) {
+		case "and":
+			if !satisfied {
+				return false, nil
+			}
+		case "or":
+			if satisfied {
+				return true, nil
+			}
+		case "nor":
+			if satisfied {
+				return false, nil
+			}
+		}
+	}
+
+	switch strings.ToLower(op) {
+	case "and", "nor":
+		return true, nil
+	case "xor":
+		return satisfiedCount == 1, nil
+	default:
+		return false, nil
+	}
+}
+
+// evaluateField evaluates a field-scoped condition such as {"name": {"eq": "alice"}}.
+// Only objects can satisfy it: field is a keyPath relative to the object, and the value found there must
+// satisfy operand, which is either a nested condition map or a plain value compared for equality.
+func (j *JsonMapper) evaluateField(value interface{}, field string, operand interface{}) (bool, error) {
+	if _, ok := value.(map[string]interface{}); !ok {
+		return false, nil
+	}
+	keys := splitKeyPath(field)
+	fieldValue, ok := lookupIn(value, keys)
+	if !ok {
+		return false, nil
+	}
+
+	switch operand.(type) {
+	case map[string]interface{}, map[string][]map[string]interface{}:
+		return j.evaluateCondition(fieldValue, keys[len(keys)-1], operand)
+	default:
+		return conditionEqual(fieldValue, operand), nil
+	}
+}
+
+// isLogicalOp reports whether op is one of the logical operators combining nested conditions.
+func isLogicalOp(op string) bool {
+	switch strings.ToLower(op) {
+	case "and", "or", "xor", "nor":
+		return true
+	default:
+		return false
+	}
+}
+
+// isNotOp reports whether op is the unary "not" operator negating a nested condition.
+func isNotOp(op string) bool {
+	return strings.ToLower(op) == "not"
+}
+
+// conditionList converts the operand of a logical operator into a list of nested conditions.
+func conditionList(operand interface{}) ([]interface{}, bool) {
+	switch list := operand.(type) {
+	case []interface{}:
+		return list, true
+	case []map[string]interface{}:
+		subConditions := make([]interface{}, len(list))
+		for i, sub := range list {
+			subConditions[i] = sub
+		}
+		return subConditions, true
+	default:
+		return nil, false
+	}
 }
 
 // checkCondition evaluates a single comparison operation between a value and a threshold.
 // This function supports "eq" (equal), "neq" (not equal), "lt" (less than), "lte" (less than or equal),
 // "gt" (greater than), and "gte" (greater than or equal) operations. The function is designed
-// to work with numeric values but also supports equality and inequality checks for other data types.
+// to work with numeric values but also supports equality and inequality checks for other data types;
+// ordering comparisons are never satisfied by non-numeric values, so they can be combined with other
+// operations in logical expressions. "neq" is the exact negation of "eq", so numbers of different Go types
+// are compared by value.
+// The "in" operation expects a slice of candidates as threshold and is satisfied if any candidate equals the value;
+// "nin" is satisfied if none of them does. The "regex" operation matches string values against a pattern,
+// given either as a string or as a compiled *regexp.Regexp; non-string values never match.
+// "startsWith" and "endsWith" check string values for a prefix or suffix.
+// The "between" operation expects a two-element slice (inclusive) or a Range and matches numbers within it.
+// The "contains" operation checks for a substring in strings and for an equal element in arrays.
+// "ieq" and "icontains" behave like "eq" and "contains" for strings but ignore case.
+// The "exists" operation matches objects in which a field is present: the threshold is either a field
+// name (e.g. "name" or "meta.id") that must exist, or a map of field names to the expected presence,
+// e.g. {"exists": map[string]interface{}{"name": false}} for objects lacking a name. Other values never match.
+// The "type" operation compares the JSON kind of the value with a Kind or its name, such as "string" or "object".
+// The "key" operation ignores the value and applies its operand, either a key name or nested conditions
+// such as {"regex": "^child"}, to the key under which the value is stored.
+// Objects and arrays only satisfy the operations listed in containerOps.
 //
 // Parameters:
 // - value: The value to be compared.
+// - key: The object key or stringified array index under which the value is stored.
 // - op: A string representing the comparison operation.
 // - threshold: The value to compare against.
 //
 // Returns:
 // - A boolean indicating the result of the comparison.
 // - An error if the operation is not supported for the given value types or if an error occurs during comparison.
-func (j *JsonMapper) checkCondition(value interface{}, op string, threshold interface{}) (bool, error) {
+func (j *JsonMapper) checkCondition(value interface{}, key string, op string, threshold interface{}) (bool, error) {
 	vValue := reflect.ValueOf(value)
 	vThreshold := reflect.ValueOf(threshold)
 
+	if op == "key" {
+		if name, ok := threshold.(string); ok {
+			return key == name, nil
+		}
+		return j.evaluateCondition(key, "", threshold)
+	}
+
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		if !containerOps[op] {
+			return false, nil
+		}
+	}
+
 	switch op {
 	case "eq":
-		if isNumeric(value) && isNumeric(threshold) {
-			valueFloat, err := convertToFloat64(value)
-			if err != nil {
-				return false, err
+		return conditionEqual(value, threshold), nil
+	case "ieq":
+		str, ok := value.(string)
+		other, isString := threshold.(string)
+		return ok && isString && strings.EqualFold(str, other), nil
+	case "icontains":
+		other, isString := threshold.(string)
+		if !isString {
+			return false, fmt.Errorf("operation %s requires a string operand", op)
+		}
+		switch v := value.(type) {
+		case string:
+			return strings.Contains(strings.ToLower(v), strings.ToLower(other)), nil
+		case []interface{}:
+			for _, element := range v {
+				if str, ok := element.(string); ok && strings.EqualFold(str, other) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "contains":
+		switch v := value.(type) {
+		case string:
+			substr, ok := threshold.(string)
+			return ok && strings.Contains(v, substr), nil
+		case []interface{}:
+			for _, element := range v {
+				if conditionEqual(element, threshold) {
+					return true, nil
+				}
 			}
-			thresholdFloat, err := convertToFloat64(threshold)
+		}
+		return false, nil
+	case "exists":
+		if _, ok := value.(map[string]interface{}); !ok {
+			return false, nil
+		}
+		switch fields := threshold.(type) {
+		case string:
+			return fieldExists(value, fields), nil
+		case map[string]bool:
+			for field, want := range fields {
+				if fieldExists(value, field) != want {
+					return false, nil
+				}
+			}
+			return true, nil
+		case map[string]interface{}:
+			for field, want := range fields {
+				wantBool, ok := want.(bool)
+				if !ok {
+					return false, fmt.Errorf("operation %s requires boolean flags", op)
+				}
+				if fieldExists(value, field) != wantBool {
+					return false, nil
+				}
+			}
+			return true, nil
+		default:
+			return false, fmt.Errorf("operation %s requires a field name or a map of field flags", op)
+		}
+	case "between":
+		var r Range
+		switch bounds := threshold.(type) {
+		case Range:
+			r = bounds
+		default:
+			pair, ok := toInterfaceSlice(threshold)
+			if !ok || len(pair) != 2 {
+				return false, fmt.Errorf("operation %s requires a two-element slice or a Range", op)
+			}
+			r = Range{Min: pair[0], Max: pair[1]}
+		}
+		if !isNumeric(r.Min) || !isNumeric(r.Max) {
+			return false, fmt.Errorf("operation %s requires numeric bounds", op)
+		}
+		if !isNumeric(value) {
+			return false, nil
+		}
+		return r.contains(value), nil
+	case "type":
+		switch kind := threshold.(type) {
+		case Kind:
+			return kindOf(value) == kind, nil
+		case string:
+			return kindOf(value).String() == kind, nil
+		default:
+			return false, fmt.Errorf("operation %s requires a type name", op)
+		}
+	case "startsWith", "endsWith":
+		str, ok := value.(string)
+		affix, isString := threshold.(string)
+		if !isString {
+			return false, fmt.Errorf("operation %s requires a string operand", op)
+		}
+		if !ok {
+			return false, nil
+		}
+		if op == "startsWith" {
+			return strings.HasPrefix(str, affix), nil
+		}
+		return strings.HasSuffix(str, affix), nil
+	case "regex":
+		str, ok := value.(string)
+		if !ok {
+			return false, nil
+		}
+		switch pattern := threshold.(type) {
+		case *regexp.Regexp:
+			return pattern.MatchString(str), nil
+		case string:
+			re, err := regexp.Compile(pattern)
 			if err != nil {
 				return false, err
 			}
-			return valueFloat == thresholdFloat, nil
+			return re.MatchString(str), nil
+		default:
+			return false, fmt.Errorf("operation %s requires a string pattern", op)
 		}
-
-		return reflect.DeepEqual(value, threshold), nil
-	case "neq":
-		if reflect.TypeOf(value) != reflect.TypeOf(threshold) {
-			return true, nil
+	case "in", "nin":
+		candidates, ok := toInterfaceSlice(threshold)
+		if !ok {
+			return false, fmt.Errorf("operation %s requires a slice of candidates", op)
+		}
+		found := false
+		for _, candidate := range candidates {
+			if conditionEqual(value, candidate) {
+				found = true
+				break
+			}
 		}
-		return !reflect.DeepEqual(value, threshold), nil
+		return found == (op == "in"), nil
+	case "neq":
+		return !conditionEqual(value, threshold), nil
 
 	case "lt", "lte", "gt", "gte":
-		if vValue.Kind().String() == "int" || vValue.Kind().String() == "float64" &&
-			(vThreshold.Kind().String() == "int" || vThreshold.Kind().String() == "float64") {
-			return compareNumericUsingReflect(vValue, vThreshold, op)
-		} else {
+		if !isNumeric(threshold) {
 			return false, fmt.Errorf("comparison %s not supported for non-numeric types", op)
 		}
+		if !isNumeric(value) {
+			return false, nil
+		}
+		return compareNumericUsingReflect(vValue, vThreshold, op)
 	default:
 		return false, fmt.Errorf("unsupported operation: %s", op)
 	}
 }
 
+// Range is the operand of the "between" condition operation.
+// Both bounds are inclusive unless ExcludeMin or ExcludeMax is set.
+// A plain two-element slice such as []interface{}{10, 20} is accepted as an inclusive range.
+type Range struct {
+	Min, Max               interface{}
+	ExcludeMin, ExcludeMax bool
+}
+
+// contains reports whether the numeric value lies within the range.
+func (r Range) contains(value interface{}) bool {
+	v, _ := convertToFloat64(value)
+	lo, _ := convertToFloat64(r.Min)
+	hi, _ := convertToFloat64(r.Max)
+	if v < lo || (r.ExcludeMin && v == lo) {
+		return false
+	}
+	if v > hi || (r.ExcludeMax && v == hi) {
+		return false
+	}
+	return true
+}
+
+// comparisonOps lists the operations evaluated by checkCondition.
+// Keys of a condition map that are neither comparison nor logical operations are treated as field names,
+// unless checkConditionKeys rejects them as misspelled operations.
+var comparisonOps = map[string]bool{
+	"eq": true, "neq": true, "lt": true, "lte": true, "gt": true, "gte": true,
+	"in": true, "nin": true, "between": true, "regex": true, "startsWith": true, "endsWith": true,
+	"contains": true, "ieq": true, "icontains": true, "exists": true, "type": true, "key": true,
+}
+
+// containerOps lists the operations that can be satisfied by an object or array value.
+// All other operations only ever match scalar values.
+var containerOps = map[string]bool{
+	"contains":  true,
+	"icontains": true,
+	"exists":    true,
+	"type":      true,
+	"key":       true,
+}
+
+// usesContainerOps reports whether conditions contain an operation that applies to objects or arrays,
+// at any nesting level, in which case containers are evaluated as values in addition to their children.
+func usesContainerOps(conditions interface{}) bool {
+	switch cond := conditions.(type) {
+	case map[string]interface{}:
+		for op, operand := range cond {
+			if containerOps[op] || isFieldOp(op) {
+				return true
+			}
+			if isNotOp(op) && usesContainerOps(operand) {
+				return true
+			}
+			if isLogicalOp(op) {
+				subConditions, _ := conditionList(operand)
+				for _, sub := range subConditions {
+					if usesContainerOps(sub) {
+						return true
+					}
+				}
+			}
+		}
+	case map[string][]map[string]interface{}:
+		for _, subConditions := range cond {
+			for _, sub := range subConditions {
+				if usesContainerOps(sub) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isFieldOp reports whether a condition key names a field rather than an operation.
+func isFieldOp(op string) bool {
+	return !comparisonOps[op] && !isLogicalOp(op) && !isNotOp(op)
+}
+
+// checkConditionKeys rejects keys of a condition map that are most likely misspelled operations rather than
+// field names: keys that match an operation apart from case or surrounding spaces, such as "Eq" or "gte ",
+// and field names next to comparison operations, as in {"gt": 1, "lte": 5, "max": 9}, which would otherwise
+// silently turn into a field condition that never matches. An empty key names no field and is rejected as well.
+func checkConditionKeys(cond map[string]interface{}) error {
+	hasComparison := false
+	for op := range cond {
+		if comparisonOps[op] {
+			hasComparison = true
+			break
+		}
+	}
+	for _, op := range sortedKeys(cond) {
+		if !isFieldOp(op) {
+			continue
+		}
+		if op == "" {
+			return fmt.Errorf("empty field name in condition")
+		}
+		if hasComparison || resemblesOperation(op) {
+			return fmt.Errorf("unsupported operation: %q", op)
+		}
+	}
+	return nil
+}
+
+// resemblesOperation reports whether op equals the name of an operation when case and surrounding spaces are ignored.
+func resemblesOperation(op string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(op))
+	if isLogicalOp(normalized) || isNotOp(normalized) {
+		return true
+	}
+	for name := range comparisonOps {
+		if strings.ToLower(name) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldExists reports whether field, a keyPath relative to value, resolves within value.
+func fieldExists(value interface{}, field string) bool {
+	_, ok := lookupIn(value, splitKeyPath(field))
+	return ok
+}
+
+// compileConditions returns a copy of conditions in which every "regex" pattern has been compiled,
+// at any nesting level, so a pattern is compiled once per query instead of once per evaluated value.
+// Returns an error if a pattern is not a valid regular expression.
+func compileConditions(conditions interface{}) (interface{}, error) {
+	switch cond := conditions.(type) {
+	case map[string]interface{}:
+		if err := checkConditionKeys(cond); err != nil {
+			return nil, err
+		}
+		compiled := make(map[string]interface{}, len(cond))
+		for op, v := range cond {
+			switch {
+			case op == "regex":
+				pattern, ok := v.(string)
+				if !ok {
+					compiled[op] = v
+					continue
+				}
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regex %q: %v", pattern, err)
+				}
+				compiled[op] = re
+			case op == "key" || isNotOp(op) || isFieldOp(op):
+				c, err := compileConditions(v)
+				if err != nil {
+					return nil, err
+				}
+				compiled[op] = c
+			case isLogicalOp(op):
+				subConditions, ok := conditionList(v)
+				if !ok {
+					compiled[op] = v
+					continue
+				}
+				compiledSub := make([]interface{}, len(subConditions))
+				for i, sub := range subConditions {
+					c, err := compileConditions(sub)
+					if err != nil {
+						return nil, err
+					}
+					compiledSub[i] = c
+				}
+				compiled[op] = compiledSub
+			default:
+				compiled[op] = v
+			}
+		}
+		return compiled, nil
+	case map[string][]map[string]interface{}:
+		generic := make(map[string]interface{}, len(cond))
+		for logicalOp, subConditions := range cond {
+			if !isLogicalOp(logicalOp) {
+				return nil, fmt.Errorf("unsupported logical operation: %s", logicalOp)
+			}
+			generic[logicalOp] = subConditions
+		}
+		return compileConditions(generic)
+	default:
+		return conditions, nil
+	}
+}
+
+// conditionEqual reports whether value equals threshold as defined by the "eq" operation:
+// numbers of any type are compared by value, everything else with reflect.DeepEqual.
+func conditionEqual(value, threshold interface{}) bool {
+	if isNumeric(value) && isNumeric(threshold) {
+		valueFloat, _ := convertToFloat64(value)
+		thresholdFloat, _ := convertToFloat64(threshold)
+		return valueFloat == thresholdFloat
+	}
+	return reflect.DeepEqual(value, threshold)
+}
+
+// toInterfaceSlice converts any slice or array value, such as []int or []string, into a []interface{}.
+// Returns false if v is not a slice or array.
+func toInterfaceSlice(v interface{}) ([]interface{}, bool) {
+	if s, ok := v.([]interface{}); ok {
+		return s, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	s := make([]interface{}, rv.Len())
+	for i := range s {
+		s[i] = rv.Index(i).Interface()
+	}
+	return s, true
+}
+
 // compareNumericUsingReflect performs a numeric comparison between two reflect.Value instances
 // based on the specified operation. This function is utilized internally by checkCondition
 // to handle numeric comparisons using reflection. Supported operations include
@@ -272,6 +987,8 @@ func convertToFloat64(value interface{}) (float64, error) {
 	switch v := value.(type) {
 	case float64:
 		return v, nil
+	case json.Number:
+		return v.Float64()
 	case float32:
 		return float64(v), nil
 	case int, int8, int16, int32, int64:
@@ -295,7 +1012,7 @@ func convertToFloat64(value interface{}) (float64, error) {
 // - A boolean indicating whether the value is of a numeric type.
 func isNumeric(value interface{}) bool {
 	switch value.(type) {
-	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
 		return true
 	default:
 		return false
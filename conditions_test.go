@@ -0,0 +1,342 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// findAll runs FindAllWithCondition on the nested test document and returns the sorted result paths.
+func findAll(t *testing.T, keyPath string, conditions interface{}) []string {
+	t.Helper()
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	paths, err := j.FindAllWithCondition(keyPath, conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestConditionIn(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"in": []interface{}{1, 5, "world"}})
+	want := []string{"testData.nested.string", "testData.s2[0].id", "testData.sliced[0]", "testData.sliced[4]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData.s2", map[string]interface{}{"in": []string{"alice", "bob"}})
+	want = []string{"testData.s2[0].name", "testData.s2[1].name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("typed slice: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionNin(t *testing.T) {
+	got := findAll(t, "testData.sliced", map[string]interface{}{"nin": []interface{}{1, 2, 3}})
+	want := []string{"testData.sliced[3]", "testData.sliced[4]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestConditionNeq(t *testing.T) {
+	got := findAll(t, "testData.sliced", map[string]interface{}{"neq": 3})
+	want := []string{"testData.sliced[0]", "testData.sliced[1]", "testData.sliced[3]", "testData.sliced[4]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("int threshold: got %v, want %v", got, want)
+	}
+
+	j, _ := NewJsonMapStr(`{"u": [{"age": 25}, {"age": 26}]}`, UseNumber())
+	paths, err := j.FindAllWithCondition("u", map[string]interface{}{"age": map[string]interface{}{"neq": 25}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"u[1]"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("json.Number value: got %v, want %v", paths, want)
+	}
+}
+
+func TestConditionNeqMatchesNin(t *testing.T) {
+	for _, threshold := range []interface{}{3, 3.0, int64(5), "world", true, nil} {
+		neq := findAll(t, "testData", map[string]interface{}{"neq": threshold})
+		nin := findAll(t, "testData", map[string]interface{}{"nin": []interface{}{threshold}})
+		if !reflect.DeepEqual(neq, nin) {
+			t.Errorf("%#v: neq = %v, nin = %v", threshold, neq, nin)
+		}
+	}
+}
+
+func TestConditionRegex(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"regex": "^(al|bo)"})
+	want := []string{"testData.s2[0].name", "testData.s2[1].name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	if _, err := j.FindAllWithCondition("", map[string]interface{}{"regex": "("}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestConditionContains(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"contains": "ell"})
+	want := []string{"testData.string"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("substring: got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData", map[string]interface{}{"contains": 3})
+	want = []string{"testData.sliced"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("array element: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionStartsEndsWith(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"startsWith": "wor"})
+	if want := []string{"testData.nested.string"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("startsWith: got %v, want %v", got, want)
+	}
+	got = findAll(t, "testData", map[string]interface{}{"endsWith": "dy"})
+	if want := []string{"testData.s2[2].name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("endsWith: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionExists(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"s2": [{"id": 1, "name": "alice"}, {"id": 2}, {"id": 3, "name": null}]}`)
+
+	paths, err := j.FindAllWithCondition("s2", map[string]interface{}{"exists": "name"})
+	sort.Strings(paths)
+	if want := []string{"s2[0]", "s2[2]"}; err != nil || !reflect.DeepEqual(paths, want) {
+		t.Errorf("has name: got %v, %v, want %v", paths, err, want)
+	}
+
+	paths, err = j.FindAllWithCondition("s2", map[string]interface{}{"exists": map[string]interface{}{"name": false}})
+	if want := []string{"s2[1]"}; err != nil || !reflect.DeepEqual(paths, want) {
+		t.Errorf("lacks name: got %v, %v, want %v", paths, err, want)
+	}
+}
+
+func TestConditionType(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"type": "string"})
+	want := []string{"testData.nested.string", "testData.s2[0].name", "testData.s2[1].name", "testData.s2[2].name", "testData.string"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("string: got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData", map[string]interface{}{"type": KindArray})
+	if want := []string{"testData.s2", "testData.sliced"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("array: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionBetween(t *testing.T) {
+	got := findAll(t, "testData.sliced", map[string]interface{}{"between": []int{2, 4}})
+	want := []string{"testData.sliced[1]", "testData.sliced[2]", "testData.sliced[3]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inclusive: got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData.sliced", map[string]interface{}{"between": Range{Min: 2, Max: 4, ExcludeMin: true}})
+	want = []string{"testData.sliced[2]", "testData.sliced[3]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("exclusive min: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionCaseInsensitive(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"ieq": "HELLO"})
+	if want := []string{"testData.string"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ieq: got %v, want %v", got, want)
+	}
+	got = findAll(t, "testData.s2", map[string]interface{}{"icontains": "LIC"})
+	if want := []string{"testData.s2[0].name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("icontains: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionKey(t *testing.T) {
+	got := findAll(t, "testData", map[string]interface{}{"key": map[string]interface{}{"regex": "^s"}})
+	want := []string{"testData.nested.string", "testData.s2", "testData.sliced", "testData.string"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("regex: got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData.s2", map[string]interface{}{"key": "id"})
+	want = []string{"testData.s2[0].id", "testData.s2[1].id", "testData.s2[2].id"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("name: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionNestedLogical(t *testing.T) {
+	conditions := map[string]interface{}{
+		"or": []interface{}{
+			map[string]interface{}{"and": []interface{}{
+				map[string]interface{}{"gt": 10},
+				map[string]interface{}{"lt": 20},
+			}},
+			map[string]interface{}{"eq": "hello"},
+		},
+	}
+	got := findAll(t, "testData", conditions)
+	want := []string{"testData.nested.number", "testData.string"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nested: got %v, want %v", got, want)
+	}
+
+	typed := map[string][]map[string]interface{}{
+		"and": {{"gt": 1}, {"lt": 4}},
+	}
+	got = findAll(t, "testData.sliced", typed)
+	want = []string{"testData.sliced[1]", "testData.sliced[2]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("typed: got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData", map[string]interface{}{"key": "number", "gt": 20})
+	if want := []string{"testData.number"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("implicit and: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionNot(t *testing.T) {
+	conditions := map[string]interface{}{
+		"not": map[string]interface{}{"or": []interface{}{
+			map[string]interface{}{"lt": 2},
+			map[string]interface{}{"gt": 4},
+		}},
+	}
+	got := findAll(t, "testData.sliced", conditions)
+	want := []string{"testData.sliced[1]", "testData.sliced[2]", "testData.sliced[3]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindAllWithConditionValues(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	matches, err := j.FindAllWithConditionValues("testData.s2", map[string]interface{}{"eq": "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Match{{Path: "testData.s2[1].name", Value: "bob", ParentPath: "testData.s2[1]"}}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("got %+v, want %+v", matches, want)
+	}
+}
+
+func TestConditionFieldScoped(t *testing.T) {
+	got := findAll(t, "testData.s2", map[string]interface{}{
+		"name": map[string]interface{}{"regex": "^[ab]"},
+		"id":   map[string]interface{}{"gt": 1},
+	})
+	if want := []string{"testData.s2[1]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = findAll(t, "testData", map[string]interface{}{"name": "cindy"})
+	if want := []string{"testData.s2[2]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("implicit eq: got %v, want %v", got, want)
+	}
+}
+
+func TestConditionUnknownOperation(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	for _, conditions := range []map[string]interface{}{
+		{"age": map[string]interface{}{"gte ": 3}},
+		{"Eq": 1},
+		{"NOT ": map[string]interface{}{"eq": 1}},
+		{"gt": 1, "lte": 5, "max": 9},
+		{"or": []interface{}{map[string]interface{}{"startswith": "a"}}},
+	} {
+		_, err := j.FindAllWithCondition("testData", conditions)
+		if err == nil || !strings.Contains(err.Error(), "unsupported operation") {
+			t.Errorf("%v: error = %v, want unsupported operation", conditions, err)
+		}
+	}
+
+	// Field conditions combined with logical operations are still accepted.
+	got := findAll(t, "testData.s2", map[string]interface{}{
+		"name": "cindy",
+		"or":   []interface{}{map[string]interface{}{"id": 3}, map[string]interface{}{"id": 4}},
+	})
+	if want := []string{"testData.s2[2]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestConditionEmptyFieldName(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	for _, conditions := range []map[string]interface{}{
+		{"": map[string]interface{}{"eq": 1}},
+		{"or": []interface{}{map[string]interface{}{"": 1}}},
+	} {
+		_, err := j.FindAllWithCondition("", conditions)
+		if err == nil || !strings.Contains(err.Error(), "empty field name") {
+			t.Errorf("%v: error = %v, want empty field name", conditions, err)
+		}
+	}
+}
+
+func TestRemoveAllWithCondition(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": null, "b": "", "c": {"d": "", "e": 1, "f": [null, 2, "", 3]}}`)
+
+	n, err := j.RemoveAllWithCondition("", map[string]interface{}{"in": []interface{}{nil, ""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"c":{"e":1,"f":[2,3]}}`; n != 5 || j.Print() != want {
+		t.Errorf("removed %d, got %s, want %s", n, j.Print(), want)
+	}
+
+	j, _ = NewJsonMapStr(test_nested_json_string)
+	n, err = j.RemoveAllWithCondition("testData.s2", map[string]interface{}{"id": map[string]interface{}{"gte": 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := j.Find("testData.s2"); n != 2 || len(got.([]interface{})) != 1 {
+		t.Errorf("removed %d, left %v", n, got)
+	}
+
+	if _, err := j.RemoveAllWithCondition("testData", map[string]interface{}{"gt": "x"}); err == nil {
+		t.Error("expected error for invalid threshold")
+	}
+}
+
+func TestUpdateAllWithCondition(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"owner": "a@example.com", "users": [{"email": "b@example.com", "age": 3}, {"email": "none"}]}`)
+
+	n, err := j.UpdateAllWithCondition("", map[string]interface{}{"regex": `^[^@]+@[^@]+$`}, "[redacted]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"owner":"[redacted]","users":[{"age":3,"email":"[redacted]"},{"email":"none"}]}`
+	if n != 2 || j.Print() != want {
+		t.Errorf("updated %d, got %s, want %s", n, j.Print(), want)
+	}
+
+	double := func(old interface{}) (interface{}, error) {
+		return old.(float64) * 2, nil
+	}
+	if n, err = j.UpdateAllWithCondition("users", map[string]interface{}{"type": "number"}, double); err != nil || n != 1 {
+		t.Fatalf("updated %d, err %v", n, err)
+	}
+	if age, _ := j.Find("users[0].age"); age != 6.0 {
+		t.Errorf("age = %v, want 6", age)
+	}
+
+	fail := func(old interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("boom")
+	}
+	before := j.Print()
+	if _, err := j.UpdateAllWithCondition("", map[string]interface{}{"eq": "none"}, fail); err == nil || j.Print() != before {
+		t.Errorf("expected error and unchanged document, got %v %s", err, j.Print())
+	}
+}
@@ -0,0 +1,150 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// normalizeValue converts a value decoded from another format (YAML, TOML, msgpack, ...)
+// into the representation produced by encoding/json: objects become map[string]interface{}
+// with keys formatted by fmt.Sprint, arrays become []interface{}, numbers become float64
+// and times become RFC 3339 strings.
+func normalizeValue(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case nil, bool, string, float64, json.Number:
+		return value, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for k, child := range value {
+			normalized, err := normalizeValue(child)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = normalized
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, child := range value {
+			normalized, err := normalizeValue(child)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = normalized
+		}
+		return out, nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	case []byte:
+		return string(value), nil
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(value).Float64()
+		return f, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Map:
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			normalized, err := normalizeValue(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(iter.Key().Interface())] = normalized
+		}
+		return out, nil
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			normalized, err := normalizeValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out[i] = normalized
+		}
+		return out, nil
+	}
+
+	// Fall back to a JSON round trip for anything else, e.g. structs.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// normalizeDocument normalizes a decoded document and checks that its root is an object.
+// format names the source format in the returned error.
+func normalizeDocument(v interface{}, format string) (map[string]interface{}, error) {
+	normalized, err := normalizeValue(v)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := normalized.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s document is not an object", format)
+	}
+	return m, nil
+}
+
+// wholeNumber reports whether f has no fractional part and can be represented exactly as an int64,
+// returning the integer value. Encoders for formats with a separate integer type use it so that
+// numbers such as 8080 are not written as floats.
+func wholeNumber(f float64) (int64, bool) {
+	if f != math.Trunc(f) || math.Abs(f) >= 1<<53 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// nativeNumber converts a json.Number into an int64 if it is an integer that fits, and a float64 otherwise.
+func nativeNumber(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// nativeNumbers copies v, replacing every json.Number with its int64 or float64 value,
+// for encoders that would otherwise write json.Number as a string.
+func nativeNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for k, child := range value {
+			out[k] = nativeNumbers(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, child := range value {
+			out[i] = nativeNumbers(child)
+		}
+		return out
+	case json.Number:
+		return nativeNumber(value)
+	default:
+		return value
+	}
+}
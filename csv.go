@@ -0,0 +1,114 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// NewJsonMapCSV initializes a new JsonMapper instance from CSV data whose first row is a header.
+// Every following row becomes an object mapping the header names to the row's fields,
+// and the resulting array is stored at keyPath (e.g. "testData.s2"). All values are strings.
+// Returns an error if the CSV is malformed, has no header row, or keyPath is empty.
+func NewJsonMapCSV(data []byte, keyPath string) (*JsonMapper, error) {
+	if keyPath == "" {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidPath)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV data has no header row")
+	}
+
+	header := records[0]
+	rows := make([]interface{}, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	j := &JsonMapper{m: map[string]interface{}{}}
+	if err := j.add(keyPath, rows); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// WriteCSV saves the array of objects located at keyPath to a file as CSV.
+// The header row lists the union of the objects' keys in sorted order, and each object becomes one row.
+// Missing keys and null values are written as empty fields, numbers and booleans in their JSON form,
+// and nested objects or arrays as JSON text.
+// Returns an error if the value at keyPath is not an array of objects or if writing the file fails.
+func (j *JsonMapper) WriteCSV(keyPath, filePath string) error {
+	value, err := j.Find(keyPath)
+	if err != nil {
+		return err
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: value at %s is not an array", ErrTypeMismatch, keyPath)
+	}
+
+	columns := make(map[string]bool)
+	for i, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: element %d at %s is not an object", ErrTypeMismatch, i, keyPath)
+		}
+		for k := range object {
+			columns[k] = true
+		}
+	}
+	header := make([]string, 0, len(columns))
+	for k := range columns {
+		header = append(header, k)
+	}
+	sort.Strings(header)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(header)
+	for _, item := range items {
+		object := item.(map[string]interface{})
+		record := make([]string, len(header))
+		for i, k := range header {
+			if record[i], err = csvField(object[k]); err != nil {
+				return fmt.Errorf("failed to marshal CSV: %v", err)
+			}
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to marshal CSV: %v", err)
+	}
+
+	err = os.WriteFile(filePath, buf.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return nil
+}
+
+// csvField formats a value as a CSV field.
+func csvField(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
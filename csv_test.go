@@ -0,0 +1,41 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	j.Add("testData.s2[1].tags", []interface{}{"x", "y"})
+	j.Add("testData.s2[2].name", "cindy, jr.")
+
+	path := filepath.Join(t.TempDir(), "users.csv")
+	if err := j.WriteCSV("testData.s2", path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "id,name,tags\n1,alice,\n2,bob,\"[\"\"x\"\",\"\"y\"\"]\"\n3,\"cindy, jr.\",\n"
+	if string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	imported, err := NewJsonMapCSV(data, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := imported.FindString("users[2].name"); name != "cindy, jr." {
+		t.Errorf("name = %q", name)
+	}
+	if id, _ := imported.FindString("users[0].id"); id != "1" {
+		t.Errorf("id = %q", id)
+	}
+
+	if err := j.WriteCSV("testData.sliced", path); err == nil {
+		t.Error("expected error for an array of numbers")
+	}
+	if _, err := NewJsonMapCSV([]byte("a,b\n1\n"), "rows"); err == nil {
+		t.Error("expected error for a short row")
+	}
+}
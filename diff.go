@@ -0,0 +1,126 @@
+package jsonmapper_v2
+
+import (
+	"math"
+	"reflect"
+	"sort"
+)
+
+// DiffPaths compares this document with other and reports the keyPaths that differ.
+// added lists paths that only exist in other, removed lists paths that only exist in this document,
+// and changed lists paths present in both whose values differ.
+// Objects and arrays are compared recursively, so only the most specific differing paths are reported.
+// Numbers are compared by value, so 1 and 1.0 are considered equal.
+// Paths use the same notation as FindAllWithCondition (e.g. "testData.s2[0].id") and are sorted.
+func (j *JsonMapper) DiffPaths(other *JsonMapper) (added, removed, changed []string) {
+	j.materialize()
+	var otherRoot map[string]interface{}
+	if other != nil {
+		other.materialize()
+		otherRoot = other.m
+	}
+
+	var diff func(a, b interface{}, path string)
+	diff = func(a, b interface{}, path string) {
+		switch aValue := a.(type) {
+		case map[string]interface{}:
+			bValue, ok := b.(map[string]interface{})
+			if !ok {
+				changed = append(changed, path)
+				return
+			}
+			for k, v := range aValue {
+				if bv, ok := bValue[k]; ok {
+					diff(v, bv, joinKey(path, k))
+				} else {
+					removed = append(removed, joinKey(path, k))
+				}
+			}
+			for k := range bValue {
+				if _, ok := aValue[k]; !ok {
+					added = append(added, joinKey(path, k))
+				}
+			}
+		case []interface{}:
+			bValue, ok := b.([]interface{})
+			if !ok {
+				changed = append(changed, path)
+				return
+			}
+			for i := 0; i < len(aValue) || i < len(bValue); i++ {
+				switch {
+				case i >= len(bValue):
+					removed = append(removed, joinIndex(path, i))
+				case i >= len(aValue):
+					added = append(added, joinIndex(path, i))
+				default:
+					diff(aValue[i], bValue[i], joinIndex(path, i))
+				}
+			}
+		default:
+			if !valuesEqual(a, b, 0) {
+				changed = append(changed, path)
+			}
+		}
+	}
+
+	diff(j.m, otherRoot, "")
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// Equal reports whether this document and other are semantically equal.
+// Key order is irrelevant, and numbers are compared by value regardless of their Go type,
+// so a document built with Add("n", 1) equals one parsed from {"n": 1.0}.
+func (j *JsonMapper) Equal(other *JsonMapper) bool {
+	return j.EqualWithTolerance(other, 0)
+}
+
+// EqualWithTolerance is like Equal but considers two numbers equal
+// when their absolute difference does not exceed tolerance.
+func (j *JsonMapper) EqualWithTolerance(other *JsonMapper, tolerance float64) bool {
+	if other == nil {
+		return false
+	}
+	j.materialize()
+	other.materialize()
+	return valuesEqual(j.m, other.m, tolerance)
+}
+
+// valuesEqual recursively compares two values, treating numbers within tolerance as equal.
+func valuesEqual(a, b interface{}, tolerance float64) bool {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		bValue, ok := b.(map[string]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		for k, v := range aValue {
+			bv, ok := bValue[k]
+			if !ok || !valuesEqual(v, bv, tolerance) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bValue, ok := b.([]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		for i := range aValue {
+			if !valuesEqual(aValue[i], bValue[i], tolerance) {
+				return false
+			}
+		}
+		return true
+	default:
+		if isNumeric(a) && isNumeric(b) {
+			aFloat, _ := convertToFloat64(a)
+			bFloat, _ := convertToFloat64(b)
+			return math.Abs(aFloat-bFloat) <= tolerance
+		}
+		return reflect.DeepEqual(a, b)
+	}
+}
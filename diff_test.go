@@ -0,0 +1,46 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffPaths(t *testing.T) {
+	a, _ := NewJsonMapStr(test_nested_json_string)
+	b := a.Clone()
+	_ = b.Add("testData.added", true)
+	_ = b.Remove("testData.bool")
+	_ = b.Set("testData.s2[1].name", "bobby")
+	_ = b.Set("testData.number", 25)
+	_ = b.Add("testData.sliced[-1]", 6)
+
+	added, removed, changed := a.DiffPaths(b)
+	if want := []string{"testData.added", "testData.sliced[5]"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("added: got %v, want %v", added, want)
+	}
+	if want := []string{"testData.bool"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed: got %v, want %v", removed, want)
+	}
+	if want := []string{"testData.s2[1].name"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed: got %v, want %v", changed, want)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a, _ := NewJsonMapStr(`{"a": {"x": 1, "y": [1, 2.5]}, "b": "s"}`)
+	b, _ := NewJsonMapObject(map[string]interface{}{
+		"b": "s",
+		"a": map[string]interface{}{"y": []interface{}{int64(1), 2.5}, "x": 1},
+	})
+	if !a.Equal(b) {
+		t.Error("expected documents to be equal")
+	}
+
+	_ = b.Set("a.y[1]", 2.5000001)
+	if a.Equal(b) {
+		t.Error("expected documents to differ")
+	}
+	if !a.EqualWithTolerance(b, 1e-6) {
+		t.Error("expected documents to be equal within tolerance")
+	}
+}
@@ -0,0 +1,25 @@
+package jsonmapper_v2
+
+import "fmt"
+
+// DisallowDuplicateKeys makes parsing fail if an object in the input contains the same key more than once,
+// instead of silently keeping the last value, which matters for security-sensitive parsers where
+// different components might otherwise disagree on the value of a key.
+func DisallowDuplicateKeys() Option {
+	return func(o *decodeOptions) {
+		o.onDuplicate = func(keyPath string) error {
+			return fmt.Errorf("duplicate key: %s", keyPath)
+		}
+	}
+}
+
+// OnDuplicateKey calls fn with the keyPath of every duplicate object key in the input, e.g. to log a warning.
+// Parsing continues and the last value of a duplicate key is kept.
+func OnDuplicateKey(fn func(keyPath string)) Option {
+	return func(o *decodeOptions) {
+		o.onDuplicate = func(keyPath string) error {
+			fn(keyPath)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	const doc = `{"role": "user", "nested": {"a": 1, "a": 2}, "list": [{"x": 1, "x": 1}], "role": "admin"}`
+
+	j, err := NewJsonMapStr(doc)
+	if err != nil || j.MustFindString("role") != "admin" {
+		t.Fatalf("default parsing should keep the last value: %v", err)
+	}
+
+	if _, err := NewJsonMapStr(doc, DisallowDuplicateKeys()); err == nil || err.Error() != "duplicate key: nested.a" {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := NewJsonMapReader(strings.NewReader(doc), DisallowDuplicateKeys()); err == nil {
+		t.Error("NewJsonMapReader: expected error")
+	}
+	if _, err := NewJsonMapLazy([]byte(doc), DisallowDuplicateKeys()); err == nil {
+		t.Error("NewJsonMapLazy: expected error")
+	}
+
+	var duplicates []string
+	j, err = NewJsonMapStr(doc, OnDuplicateKey(func(keyPath string) {
+		duplicates = append(duplicates, keyPath)
+	}))
+	if err != nil || j.MustFindString("role") != "admin" {
+		t.Fatalf("OnDuplicateKey should not fail parsing: %v", err)
+	}
+	if want := []string{"nested.a", "list[0].x", "role"}; !reflect.DeepEqual(duplicates, want) {
+		t.Errorf("duplicates = %v, want %v", duplicates, want)
+	}
+
+	if _, err := NewJsonMapStr(`{"a": 1, "b": {"a": 2}}`, DisallowDuplicateKeys()); err != nil {
+		t.Errorf("equal keys in different objects are not duplicates: %v", err)
+	}
+	if _, err := NewJsonMapStr(`{"a": `, DisallowDuplicateKeys()); err == nil || strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("syntax errors should be reported by the decoder, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncryptPaths(t *testing.T) {
+	enc, err := NewAESGCMEncrypter(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, _ := NewJsonMapStr(`{"user": "alice", "ssn": "123-45-6789", "card": {"number": 4111, "cvv": "123"}, "keys": [{"v": "a"}, {"v": "b"}]}`)
+	original := j.Print()
+
+	if err := j.EncryptPaths([]string{"ssn", "card", "keys[*].v", "missing"}, enc); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(j.Print(), "123-45-6789") || strings.Contains(j.Print(), "4111") {
+		t.Errorf("plaintext left in document: %s", j.Print())
+	}
+	if got, _ := j.FindString("user"); got != "alice" {
+		t.Errorf("user = %q", got)
+	}
+	if _, err := j.FindString("card"); err != nil {
+		t.Errorf("card should be an encrypted string: %v", err)
+	}
+
+	if err := j.DecryptPaths([]string{"ssn", "card", "keys[*].v"}, enc); err != nil {
+		t.Fatal(err)
+	}
+	if got := j.Print(); got != original {
+		t.Errorf("got %s, want %s", got, original)
+	}
+
+	// Decrypting plaintext fails without modifying the document.
+	if err := j.DecryptPaths([]string{"user", "ssn"}, enc); err == nil {
+		t.Error("expected error")
+	}
+	if got := j.Print(); got != original {
+		t.Errorf("document changed on error: %s", got)
+	}
+}
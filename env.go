@@ -0,0 +1,33 @@
+package jsonmapper_v2
+
+import "os"
+
+// ExpandEnv replaces ${VAR} and $VAR references inside every string value of the document with the values
+// of the corresponding environment variables, for 12-factor style configuration files.
+// Substitution follows os.ExpandEnv: references to unset variables are replaced by the empty string.
+// Object keys are left unchanged. The expansion is recorded as a single mutation.
+func (j *JsonMapper) ExpandEnv() error {
+	j.materialize()
+	return j.mutate("expandEnv", "", nil, func() error {
+		j.m = rewriteStrings(deepCopy(j.m), os.ExpandEnv).(map[string]interface{})
+		return nil
+	})
+}
+
+// rewriteStrings replaces every string within v with the result of fn and returns the updated value.
+// Maps and slices are updated in place.
+func rewriteStrings(v interface{}, fn func(string) string) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, child := range value {
+			value[k] = rewriteStrings(child, fn)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = rewriteStrings(child, fn)
+		}
+	case string:
+		return fn(value)
+	}
+	return v
+}
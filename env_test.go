@@ -0,0 +1,19 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("JM_HOST", "db.internal")
+	t.Setenv("JM_PORT", "5432")
+
+	j, _ := NewJsonMapStr(`{"db": {"url": "postgres://${JM_HOST}:$JM_PORT/app", "hosts": ["$JM_HOST"]},
+		"unset": "x${JM_UNSET}y", "port": 1, "${JM_HOST}": "key"}`)
+	if err := j.ExpandEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"${JM_HOST}":"key","db":{"hosts":["db.internal"],"url":"postgres://db.internal:5432/app"},"port":1,"unset":"xy"}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
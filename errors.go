@@ -0,0 +1,169 @@
+package jsonmapper_v2
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors wrapped by the errors returned from path operations and typed finders,
+// so callers can branch with errors.Is instead of matching error strings:
+//
+//	if _, err := j.FindInt("server.port"); errors.Is(err, jsonmapper_v2.ErrKeyNotFound) { ... }
+var (
+	// ErrKeyNotFound is returned when a key of a path does not exist.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrIndexOutOfRange is returned when an array index of a path is outside the array.
+	ErrIndexOutOfRange = errors.New("array index out of range")
+	// ErrTypeMismatch is returned when a value exists but does not have, or cannot be represented as,
+	// the requested type, or when a path continues below a value that is not an object or array.
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrInvalidPath is returned when a key path is malformed, e.g. empty or with a non-numeric array index.
+	ErrInvalidPath = errors.New("invalid key path")
+)
+
+// PathError describes where the traversal of a key path failed. It is returned by Find, Add, Set, Remove
+// and the other path operations, and wraps one of the sentinel errors above, so both
+//
+//	errors.Is(err, ErrKeyNotFound)
+//
+// and
+//
+//	var pathErr *PathError
+//	if errors.As(err, &pathErr) { fmt.Println(pathErr.FailedSegment) }
+//
+// work on the returned error.
+type PathError struct {
+	// FullPath is the key path that was being resolved.
+	FullPath string
+	// FailedSegment is the path segment (object key or array index) that could not be resolved.
+	FailedSegment string
+	// SegmentIndex is the zero-based position of FailedSegment in the path; "a.b[2]" has the segments a, b and 2.
+	SegmentIndex int
+	// NodeType is the kind of the value the failed segment was applied to.
+	NodeType Kind
+	// Err is the underlying error.
+	Err error
+
+	// suggestions holds the keys returned by Suggestions, computed when the error is created.
+	suggestions []string
+}
+
+// Suggestions returns up to three existing keys of the object that are spelled similarly to a missing
+// FailedSegment, closest first, or nil if the segment was not a missing object key.
+// The keys reflect the object as it was when the lookup failed.
+func (e *PathError) Suggestions() []string {
+	return e.suggestions
+}
+
+// Error returns the full path followed by the underlying error and any suggestions,
+// e.g. "user.nmae: key not found: nmae (did you mean name?)".
+func (e *PathError) Error() string {
+	msg := e.Err.Error()
+	if e.FullPath != "" {
+		msg = e.FullPath + ": " + msg
+	}
+	if suggestions := e.Suggestions(); len(suggestions) > 0 {
+		msg += " (did you mean " + strings.Join(suggestions, " or ") + "?)"
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// newPathError returns a PathError for segment, at position index of keyPath, applied to node.
+// If the segment is a missing object key, similarly spelled keys of node are collected for Suggestions.
+// The error does not keep node, so it neither pins the document in memory nor reads it after it changes.
+// Lookups that do not report their errors avoid this cost by resolving with findMiss instead.
+func newPathError(keyPath, segment string, index int, node interface{}, err error) *PathError {
+	pathErr := &PathError{FullPath: keyPath, FailedSegment: segment, SegmentIndex: index, NodeType: kindOf(node), Err: err}
+	if object, ok := node.(map[string]interface{}); ok && errors.Is(err, ErrKeyNotFound) {
+		pathErr.suggestions = similarKeys(segment, object, 3)
+	}
+	return pathErr
+}
+
+// nestedPathError adjusts a PathError returned by a recursive call of editIn, which numbers segments
+// from the start of its own keys, to the keys of the caller, which start one segment earlier.
+func nestedPathError(err error) error {
+	if pathErr, ok := err.(*PathError); ok {
+		pathErr.SegmentIndex++
+	}
+	return err
+}
+
+// pathError completes an error returned by editIn for keyPath, split into keys: a PathError gets the full path,
+// and the bare ErrKeyNotFound returned by edit callbacks for a missing last segment becomes a PathError.
+// Other errors are returned unchanged.
+func (j *JsonMapper) pathError(keyPath string, keys []string, err error) error {
+	if pathErr, ok := err.(*PathError); ok {
+		pathErr.FullPath = keyPath
+		return pathErr
+	}
+	if err == ErrKeyNotFound {
+		last := len(keys) - 1
+		parent, _ := lookupIn(j.m, keys[:last])
+		return newPathError(keyPath, keys[last], last, parent, fmt.Errorf("%w: %s", ErrKeyNotFound, keys[last]))
+	}
+	return err
+}
+
+// similarKeys returns up to limit keys of object whose edit distance to key is small relative to its length,
+// closest first. Differences in case count as no distance, so "UserID" suggests "userId".
+func similarKeys(key string, object map[string]interface{}, limit int) []string {
+	type candidate struct {
+		key      string
+		distance int
+	}
+	maxDistance := len(key) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	var candidates []candidate
+	lower := strings.ToLower(key)
+	for k := range object {
+		if d := levenshtein(lower, strings.ToLower(k)); d <= maxDistance {
+			candidates = append(candidates, candidate{k, d})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].distance != candidates[b].distance {
+			return candidates[a].distance < candidates[b].distance
+		}
+		return candidates[a].key < candidates[b].key
+	})
+
+	var keys []string
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		keys = append(keys, candidates[i].key)
+	}
+	return keys
+}
+
+// levenshtein returns the number of single-character insertions, deletions and substitutions
+// needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,110 @@
+package jsonmapper_v2
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	_, err := j.Find("testData.missing")
+	if !errors.Is(err, ErrKeyNotFound) || err.Error() != "testData.missing: key not found: missing" {
+		t.Errorf("Find(missing) = %v", err)
+	}
+	if _, err := j.Find("testData.sliced[9]"); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Find(sliced[9]) = %v", err)
+	}
+	if _, err := j.Find("testData.sliced.x"); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("Find(sliced.x) = %v", err)
+	}
+	if _, err := j.FindInt("testData.string"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("FindInt(string) = %v", err)
+	}
+	if _, err := j.FindStringSlice("testData.sliced"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("FindStringSlice(sliced) = %v", err)
+	}
+	if err := j.Remove("testData.nested.missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Remove(missing) = %v", err)
+	}
+	if err := j.Add("testData.number.x", 1); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Add below a scalar = %v", err)
+	}
+	if err := j.Set("", 1); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("Set(\"\") = %v", err)
+	}
+	if _, err := j.TypeOf("nope"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TypeOf(nope) = %v", err)
+	}
+}
+
+func TestPathError(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	tests := []struct {
+		name    string
+		err     error
+		path    string
+		segment string
+		index   int
+		kind    Kind
+	}{
+		{"Find", func() error { _, err := j.Find("testData.nested.nope.x"); return err }(), "testData.nested.nope.x", "nope", 2, KindObject},
+		{"Find index", func() error { _, err := j.Find("testData.s2[7].id"); return err }(), "testData.s2[7].id", "7", 2, KindArray},
+		{"Add", j.Add("testData.string.x", 1), "testData.string.x", "x", 2, KindString},
+		{"Remove", j.Remove("testData.s2[1].missing"), "testData.s2[1].missing", "missing", 3, KindObject},
+		{"Set", j.Set("testData.nope", 1), "testData.nope", "nope", 1, KindObject},
+	}
+	for _, tt := range tests {
+		var pathErr *PathError
+		if !errors.As(tt.err, &pathErr) {
+			t.Errorf("%s: %v is not a *PathError", tt.name, tt.err)
+			continue
+		}
+		if pathErr.FullPath != tt.path || pathErr.FailedSegment != tt.segment || pathErr.SegmentIndex != tt.index || pathErr.NodeType != tt.kind {
+			t.Errorf("%s: got %+v", tt.name, *pathErr)
+		}
+	}
+}
+
+func TestPathErrorSuggestions(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"user": {"name": "a", "names": [], "email": "b", "UserId": 1}}`)
+
+	_, err := j.Find("user.nme")
+	if err == nil || err.Error() != "user.nme: key not found: nme (did you mean name or names?)" {
+		t.Errorf("err = %v", err)
+	}
+	var pathErr *PathError
+	if _, err := j.Find("user.userid"); !errors.As(err, &pathErr) || len(pathErr.Suggestions()) != 1 || pathErr.Suggestions()[0] != "UserId" {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := j.Find("user.address"); err == nil || err.Error() != "user.address: key not found: address" {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := j.Find("user.names[3]"); !errors.As(err, &pathErr) || pathErr.Suggestions() != nil {
+		t.Errorf("index error suggests keys: %v", err)
+	}
+	if err := j.Remove("user.emial"); err == nil || err.Error() != "user.emial: key not found: emial (did you mean email?)" {
+		t.Errorf("err = %v", err)
+	}
+
+	// Suggestions describe the document at the time of the lookup.
+	_, err = j.Find("user.nme")
+	if err := j.Remove("user.names"); err != nil {
+		t.Fatal(err)
+	}
+	if err == nil || err.Error() != "user.nme: key not found: nme (did you mean name or names?)" {
+		t.Errorf("err after Remove = %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{{"", "", 0}, {"abc", "", 3}, {"kitten", "sitting", 3}, {"nmae", "name", 2}, {"héllo", "hello", 1}} {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package jsonmapper_v2
+
+import "fmt"
+
+// WithFallback sets a mapper that Find and the typed finders consult whenever a path cannot be resolved
+// in this document, which implements layered configuration: runtime settings fall back to user settings,
+// which in turn fall back to defaults.
+//
+//	user.WithFallback(defaults)
+//	runtime.WithFallback(user)
+//	port, _ := runtime.FindInt("server.port") // first hit in runtime, user, defaults
+//
+// Reads of a single path are affected: Find, the typed finders, FindPath and the inspection helpers
+// Exists, TypeOf, Keys, Len and RequirePaths. Mutations and whole-document operations such as Print,
+// Paths, Walk or Stats only see this document. Passing nil removes the fallback.
+// Returns an error if the fallback chain would lead back to this mapper.
+func (j *JsonMapper) WithFallback(defaults *JsonMapper) error {
+	for m := defaults; m != nil; m = m.fallback {
+		if m == j {
+			return fmt.Errorf("fallback chain would form a cycle")
+		}
+	}
+	j.fallback = defaults
+	return nil
+}
+
+// lookupWithFallback is lookupIn for keyPath in this document, consulting the fallback chain
+// if the path does not resolve here.
+func (j *JsonMapper) lookupWithFallback(keyPath string) (interface{}, bool) {
+	keys := splitKeyPath(keyPath)
+	for m := j; m != nil; m = m.fallback {
+		if value, ok := lookupIn(m.m, keys); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,69 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestWithFallback(t *testing.T) {
+	defaults, _ := NewJsonMapStr(`{"server": {"host": "0.0.0.0", "port": 80}, "debug": false}`)
+	user, _ := NewJsonMapStr(`{"server": {"port": 8080}}`)
+	runtime, _ := NewJsonMapStr(`{"debug": true}`)
+
+	if err := user.WithFallback(defaults); err != nil {
+		t.Fatal(err)
+	}
+	if err := runtime.WithFallback(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := runtime.FindInt("server.port"); err != nil || got != 8080 {
+		t.Errorf("server.port = %d, %v", got, err)
+	}
+	if got, err := runtime.FindString("server.host"); err != nil || got != "0.0.0.0" {
+		t.Errorf("server.host = %q, %v", got, err)
+	}
+	if got, err := runtime.FindBool("debug"); err != nil || !got {
+		t.Errorf("debug = %v, %v", got, err)
+	}
+	if _, err := runtime.Find("missing"); err == nil {
+		t.Error("expected error for a path missing from every layer")
+	}
+	if !runtime.Exists("server.port") {
+		t.Error("Exists should consult the fallback")
+	}
+
+	if err := defaults.WithFallback(runtime); err == nil {
+		t.Error("expected cycle error")
+	}
+	if err := runtime.WithFallback(nil); err != nil || runtime.FindIntOr("server.port", -1) != -1 {
+		t.Error("WithFallback(nil) should remove the fallback")
+	}
+}
+
+func TestWithFallbackInspection(t *testing.T) {
+	defaults, _ := NewJsonMapStr(`{"server": {"host": "0.0.0.0", "tags": ["a", "b"]}, "name": null}`)
+	user, _ := NewJsonMapStr(`{"name": "svc"}`)
+	if err := user.WithFallback(defaults); err != nil {
+		t.Fatal(err)
+	}
+
+	if !user.Exists("server.tags.1") || user.Exists("server.port") {
+		t.Error("Exists should see paths of the fallback")
+	}
+	if kind, err := user.TypeOf("server.tags"); err != nil || kind != KindArray {
+		t.Errorf("TypeOf(server.tags) = %v, %v", kind, err)
+	}
+	if n, err := user.Len("server.tags"); err != nil || n != 2 {
+		t.Errorf("Len(server.tags) = %d, %v", n, err)
+	}
+	if keys, err := user.Keys("server"); err != nil || len(keys) != 2 {
+		t.Errorf("Keys(server) = %v, %v", keys, err)
+	}
+	if err := user.RequirePaths("name", "server.host"); err != nil {
+		t.Error(err)
+	}
+	if err := user.RequirePaths("server.port"); err == nil {
+		t.Error("expected error for a path missing from every layer")
+	}
+	if paths := user.Paths(); len(paths) != 1 || paths[0] != "name" {
+		t.Errorf("Paths should only see the document itself, got %v", paths)
+	}
+}
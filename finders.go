@@ -0,0 +1,370 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FindTime searches for a timestamp at the given keyPath.
+// String values are parsed with each of the given layouts in turn, or with time.RFC3339 if none are given
+// (fractional seconds are accepted). Numeric values are interpreted as a Unix epoch: values whose magnitude
+// is at least 1e12 as milliseconds and smaller values as seconds, which may have a fractional part.
+// Epoch values are returned in UTC.
+// Returns an error if the path does not exist or the value cannot be parsed.
+func (j *JsonMapper) FindTime(k string, layouts ...string) (time.Time, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return j.asTime(tmp, k, layouts...)
+}
+
+// asTime converts the value found at k for FindTime.
+func (j *JsonMapper) asTime(tmp interface{}, k string, layouts ...string) (time.Time, error) {
+	if s, ok := tmp.(string); ok {
+		if len(layouts) == 0 {
+			layouts = []string{time.RFC3339}
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("%w: value at %s does not match any time layout", ErrTypeMismatch, k)
+	}
+
+	epoch, ok := toFloat64(tmp)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: value at %s is not a time", ErrTypeMismatch, k)
+	}
+	if math.Abs(epoch) >= 1e12 {
+		millis, _ := toInt64(tmp)
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	sec, frac := math.Modf(epoch)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), nil
+}
+
+// FindTimeOr is similar to FindTime but returns the defaultValue if the value is not found or not a time.
+func (j *JsonMapper) FindTimeOr(k string, defaultValue time.Time, layouts ...string) time.Time {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asTime(tmp, k, layouts...)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FindDuration searches for a duration at the given keyPath.
+// String values are parsed with time.ParseDuration (e.g. "30s", "1h5m"); numeric values are interpreted
+// as seconds and may have a fractional part.
+// Returns an error if the path does not exist or the value cannot be parsed.
+func (j *JsonMapper) FindDuration(k string) (time.Duration, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return 0, err
+	}
+	return j.asDuration(tmp, k)
+}
+
+// asDuration converts the value found at k for FindDuration.
+func (j *JsonMapper) asDuration(tmp interface{}, k string) (time.Duration, error) {
+	if s, ok := tmp.(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("%w: value at %s is not a duration: %v", ErrTypeMismatch, k, err)
+		}
+		return d, nil
+	}
+
+	seconds, ok := toFloat64(tmp)
+	if !ok {
+		return 0, fmt.Errorf("%w: value at %s is not a duration", ErrTypeMismatch, k)
+	}
+	if math.Abs(seconds) > math.MaxInt64/float64(time.Second) {
+		return 0, fmt.Errorf("%w: value at %s overflows time.Duration", ErrTypeMismatch, k)
+	}
+	return time.Duration(math.Round(seconds * float64(time.Second))), nil
+}
+
+// FindDurationOr is similar to FindDuration but returns the defaultValue if the value is not found or not a duration.
+func (j *JsonMapper) FindDurationOr(k string, defaultValue time.Duration) time.Duration {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asDuration(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FindStringSlice searches for an array of strings at the given keyPath and returns it as a []string.
+// Returns an error if the path does not exist, the value is not an array, or any element is not a string.
+func (j *JsonMapper) FindStringSlice(k string) ([]string, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return nil, err
+	}
+	return j.asStringSlice(tmp, k)
+}
+
+// asStringSlice converts the value found at k for FindStringSlice.
+func (j *JsonMapper) asStringSlice(tmp interface{}, k string) ([]string, error) {
+	slice, err := j.asSlice(tmp, k)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(slice))
+	for i, item := range slice {
+		s, ok := j.coerceString(item).(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: element %d in slice at %s is not a string", ErrTypeMismatch, i, k)
+		}
+		values[i] = s
+	}
+	return values, nil
+}
+
+// FindStringSliceOr is similar to FindStringSlice but returns the defaultValue if the value is not found
+// or not an array of strings.
+func (j *JsonMapper) FindStringSliceOr(k string, defaultValue []string) []string {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asStringSlice(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FindIntSlice searches for an array of integers at the given keyPath and returns it as an []int.
+// Unlike FindInt, elements are never truncated: it returns an error if the value is not an array
+// or any element is not a number, has a fractional part or does not fit in an int.
+func (j *JsonMapper) FindIntSlice(k string) ([]int, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return nil, err
+	}
+	return j.asIntSlice(tmp, k)
+}
+
+// asIntSlice converts the value found at k for FindIntSlice.
+func (j *JsonMapper) asIntSlice(tmp interface{}, k string) ([]int, error) {
+	slice, err := j.asSlice(tmp, k)
+	if err != nil {
+		return nil, err
+	}
+	ints := make([]int, len(slice))
+	for i, item := range slice {
+		n, err := exactInt64(j.coerceNumber(item))
+		if err != nil {
+			return nil, fmt.Errorf("%w: element %d in slice at %s %v", ErrTypeMismatch, i, k, err)
+		}
+		if n < math.MinInt || n > math.MaxInt {
+			return nil, fmt.Errorf("%w: element %d in slice at %s overflows int", ErrTypeMismatch, i, k)
+		}
+		ints[i] = int(n)
+	}
+	return ints, nil
+}
+
+// FindIntSliceOr is similar to FindIntSlice but returns the defaultValue if the value is not found
+// or not an array of integers.
+func (j *JsonMapper) FindIntSliceOr(k string, defaultValue []int) []int {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asIntSlice(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FindFloatSlice searches for an array of numbers at the given keyPath and returns it as a []float64.
+// Returns an error if the value is not an array, any element is not a number,
+// or a json.Number element (see UseNumber) is out of the float64 range.
+func (j *JsonMapper) FindFloatSlice(k string) ([]float64, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return nil, err
+	}
+	return j.asFloatSlice(tmp, k)
+}
+
+// asFloatSlice converts the value found at k for FindFloatSlice.
+func (j *JsonMapper) asFloatSlice(tmp interface{}, k string) ([]float64, error) {
+	slice, err := j.asSlice(tmp, k)
+	if err != nil {
+		return nil, err
+	}
+	floats := make([]float64, len(slice))
+	for i, item := range slice {
+		f, ok := toFloat64(j.coerceNumber(item))
+		if !ok {
+			return nil, fmt.Errorf("%w: element %d in slice at %s is not a float", ErrTypeMismatch, i, k)
+		}
+		floats[i] = f
+	}
+	return floats, nil
+}
+
+// FindFloatSliceOr is similar to FindFloatSlice but returns the defaultValue if the value is not found
+// or not an array of numbers.
+func (j *JsonMapper) FindFloatSliceOr(k string, defaultValue []float64) []float64 {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asFloatSlice(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FindUUID searches for a UUID string at the given keyPath and returns it in canonical form:
+// lowercase hex digits grouped 8-4-4-4-12 and separated by hyphens.
+// Upper case digits and the 32-digit form without hyphens are accepted.
+// Returns an error if the path does not exist or the value is not a well-formed UUID.
+func (j *JsonMapper) FindUUID(k string) (string, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return "", err
+	}
+	return j.asUUID(tmp, k)
+}
+
+// asUUID converts the value found at k for FindUUID.
+func (j *JsonMapper) asUUID(tmp interface{}, k string) (string, error) {
+	s, err := j.asString(tmp, k)
+	if err != nil {
+		return "", err
+	}
+	uuid, ok := canonicalUUID(s)
+	if !ok {
+		return "", fmt.Errorf("%w: value at %s is not a UUID", ErrTypeMismatch, k)
+	}
+	return uuid, nil
+}
+
+// FindUUIDOr is similar to FindUUID but returns the defaultValue if the value is not found or not a UUID.
+func (j *JsonMapper) FindUUIDOr(k string, defaultValue string) string {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asUUID(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// canonicalUUID validates s as a UUID with or without hyphens and returns its canonical form.
+func canonicalUUID(s string) (string, bool) {
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return "", false
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	}
+	if len(s) != 32 {
+		return "", false
+	}
+	s = strings.ToLower(s)
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return "", false
+		}
+	}
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], true
+}
+
+// FindURL searches for an absolute URL string at the given keyPath and returns it parsed.
+// Returns an error if the path does not exist, the value is not a string, it cannot be parsed
+// or it has no scheme.
+func (j *JsonMapper) FindURL(k string) (*url.URL, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return nil, err
+	}
+	return j.asURL(tmp, k)
+}
+
+// asURL converts the value found at k for FindURL.
+func (j *JsonMapper) asURL(tmp interface{}, k string) (*url.URL, error) {
+	s, err := j.asString(tmp, k)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: value at %s is not a URL: %v", ErrTypeMismatch, k, err)
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("%w: value at %s is not an absolute URL", ErrTypeMismatch, k)
+	}
+	return u, nil
+}
+
+// FindURLOr is similar to FindURL but returns the defaultValue if the value is not found or not a URL.
+func (j *JsonMapper) FindURLOr(k string, defaultValue *url.URL) *url.URL {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asURL(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FindIP searches for an IPv4 or IPv6 address string at the given keyPath and returns it parsed.
+// Returns an error if the path does not exist or the value is not an IP address.
+func (j *JsonMapper) FindIP(k string) (net.IP, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return nil, err
+	}
+	return j.asIP(tmp, k)
+}
+
+// asIP converts the value found at k for FindIP.
+func (j *JsonMapper) asIP(tmp interface{}, k string) (net.IP, error) {
+	s, err := j.asString(tmp, k)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("%w: value at %s is not an IP address", ErrTypeMismatch, k)
+	}
+	return ip, nil
+}
+
+// FindIPOr is similar to FindIP but returns the defaultValue if the value is not found or not an IP address.
+func (j *JsonMapper) FindIPOr(k string, defaultValue net.IP) net.IP {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asIP(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,167 @@
+package jsonmapper_v2
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFindTime(t *testing.T) {
+	j, _ := NewJsonMapStr(`{
+		"rfc": "2024-01-02T03:04:05.5+09:00",
+		"custom": "02/01/2024 15:04",
+		"sec": 1704164645,
+		"frac": 1704164645.25,
+		"millis": 1704164645123,
+		"bad": "yesterday",
+		"bool": true
+	}`)
+
+	want := time.Date(2024, 1, 1, 18, 4, 5, 500000000, time.UTC)
+	if got, err := j.FindTime("rfc"); err != nil || !got.Equal(want) {
+		t.Errorf("rfc = %v, %v", got, err)
+	}
+	if got, err := j.FindTime("custom", time.RFC3339, "02/01/2006 15:04"); err != nil || !got.Equal(time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)) {
+		t.Errorf("custom = %v, %v", got, err)
+	}
+	if got, err := j.FindTime("sec"); err != nil || !got.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("sec = %v, %v", got, err)
+	}
+	if got, err := j.FindTime("frac"); err != nil || got.Nanosecond() != 250000000 {
+		t.Errorf("frac = %v, %v", got, err)
+	}
+	if got, err := j.FindTime("millis"); err != nil || !got.Equal(time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC)) {
+		t.Errorf("millis = %v, %v", got, err)
+	}
+	for _, k := range []string{"bad", "bool", "missing"} {
+		if _, err := j.FindTime(k); err == nil {
+			t.Errorf("%s: expected error", k)
+		}
+	}
+	if got := j.FindTimeOr("bad", want); !got.Equal(want) {
+		t.Errorf("FindTimeOr = %v", got)
+	}
+}
+
+func TestFindDuration(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"timeout": "30s", "interval": "1h5m", "seconds": 90, "frac": 0.25, "bad": "soon", "huge": 1e20}`)
+
+	for k, want := range map[string]time.Duration{
+		"timeout":  30 * time.Second,
+		"interval": time.Hour + 5*time.Minute,
+		"seconds":  90 * time.Second,
+		"frac":     250 * time.Millisecond,
+	} {
+		if got, err := j.FindDuration(k); err != nil || got != want {
+			t.Errorf("%s = %v, %v; want %v", k, got, err, want)
+		}
+	}
+	for _, k := range []string{"bad", "huge", "missing"} {
+		if _, err := j.FindDuration(k); err == nil {
+			t.Errorf("%s: expected error", k)
+		}
+	}
+	if got := j.FindDurationOr("bad", time.Minute); got != time.Minute {
+		t.Errorf("FindDurationOr = %v", got)
+	}
+}
+
+func TestFindStringSlice(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"tags": ["a", "b"], "empty": [], "mixed": ["a", 1], "s": "a"}`)
+
+	if got, err := j.FindStringSlice("tags"); err != nil || len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("tags = %v, %v", got, err)
+	}
+	if got, err := j.FindStringSlice("empty"); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("empty = %#v, %v", got, err)
+	}
+	for _, k := range []string{"mixed", "s", "missing"} {
+		if _, err := j.FindStringSlice(k); err == nil {
+			t.Errorf("%s: expected error", k)
+		}
+	}
+	if got := j.FindStringSliceOr("mixed", []string{"x"}); len(got) != 1 || got[0] != "x" {
+		t.Errorf("FindStringSliceOr = %v", got)
+	}
+}
+
+func TestFindIntSliceAndFloatSlice(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"ints": [1, -2, 3e2], "floats": [1.5, 2], "frac": [1, 2.5], "big": [1e300], "mixed": [1, "2"]}`)
+
+	if got, err := j.FindIntSlice("ints"); err != nil || len(got) != 3 || got[0] != 1 || got[1] != -2 || got[2] != 300 {
+		t.Errorf("ints = %v, %v", got, err)
+	}
+	for _, k := range []string{"frac", "big", "mixed", "missing"} {
+		if _, err := j.FindIntSlice(k); err == nil {
+			t.Errorf("FindIntSlice(%s): expected error", k)
+		}
+	}
+	if got := j.FindIntSliceOr("frac", nil); got != nil {
+		t.Errorf("FindIntSliceOr = %v", got)
+	}
+
+	if got, err := j.FindFloatSlice("floats"); err != nil || len(got) != 2 || got[0] != 1.5 || got[1] != 2 {
+		t.Errorf("floats = %v, %v", got, err)
+	}
+	if _, err := j.FindFloatSlice("mixed"); err == nil {
+		t.Error("FindFloatSlice(mixed): expected error")
+	}
+	if got := j.FindFloatSliceOr("mixed", []float64{0}); len(got) != 1 {
+		t.Errorf("FindFloatSliceOr = %v", got)
+	}
+
+	n, _ := NewJsonMapStr(`{"ids": [9007199254740993, 9223372036854775808]}`, UseNumber())
+	if _, err := n.FindIntSlice("ids"); err == nil {
+		t.Error("expected overflow error")
+	}
+}
+
+func TestFindUUID(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": "123E4567-E89B-12D3-A456-426614174000", "b": "123e4567e89b12d3a456426614174000",
+		"bad": "123e4567-e89b-12d3-a456-42661417400g", "short": "123e4567", "n": 1}`)
+
+	want := "123e4567-e89b-12d3-a456-426614174000"
+	for _, k := range []string{"a", "b"} {
+		if got, err := j.FindUUID(k); err != nil || got != want {
+			t.Errorf("FindUUID(%s) = %q, %v", k, got, err)
+		}
+	}
+	for _, k := range []string{"bad", "short", "n", "missing"} {
+		if _, err := j.FindUUID(k); err == nil {
+			t.Errorf("FindUUID(%s): expected error", k)
+		}
+	}
+	if got := j.FindUUIDOr("bad", "x"); got != "x" {
+		t.Errorf("FindUUIDOr = %q", got)
+	}
+}
+
+func TestFindURLAndIP(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"api": "https://example.com:8443/v1?x=1", "rel": "/v1", "bad": "http://[::1", "v4": "10.0.0.1", "v6": "::1", "host": "example.com"}`)
+
+	u, err := j.FindURL("api")
+	if err != nil || u.Scheme != "https" || u.Host != "example.com:8443" || u.Path != "/v1" {
+		t.Errorf("FindURL = %v, %v", u, err)
+	}
+	for _, k := range []string{"rel", "bad", "missing"} {
+		if _, err := j.FindURL(k); err == nil {
+			t.Errorf("FindURL(%s): expected error", k)
+		}
+	}
+	if got := j.FindURLOr("rel", nil); got != nil {
+		t.Errorf("FindURLOr = %v", got)
+	}
+
+	if ip, err := j.FindIP("v4"); err != nil || ip.String() != "10.0.0.1" {
+		t.Errorf("FindIP(v4) = %v, %v", ip, err)
+	}
+	if ip, err := j.FindIP("v6"); err != nil || !ip.IsLoopback() {
+		t.Errorf("FindIP(v6) = %v, %v", ip, err)
+	}
+	if _, err := j.FindIP("host"); err == nil {
+		t.Error("FindIP(host): expected error")
+	}
+	if got := j.FindIPOr("host", net.IPv4zero); !got.Equal(net.IPv4zero) {
+		t.Errorf("FindIPOr = %v", got)
+	}
+}
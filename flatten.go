@@ -0,0 +1,123 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Flatten converts the document into a single-level map of leaf values keyed by their full keyPath,
+// e.g. {"testData.sliced[0]": 1, "testData.nested.string": "world"}.
+// Array elements use bracket notation and object keys use dot notation, matching the paths
+// returned by FindAllWithCondition. Empty objects and arrays are kept as leaf values
+// so the original structure can be rebuilt without loss.
+func (j *JsonMapper) Flatten() map[string]interface{} {
+	j.materialize()
+	flat := make(map[string]interface{})
+
+	var flatten func(value interface{}, path string)
+	flatten = func(value interface{}, path string) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 && path != "" {
+				flat[path] = map[string]interface{}{}
+				return
+			}
+			for k, child := range v {
+				flatten(child, joinKey(path, k))
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				flat[path] = []interface{}{}
+				return
+			}
+			for i, child := range v {
+				flatten(child, joinIndex(path, i))
+			}
+		default:
+			flat[path] = v
+		}
+	}
+
+	flatten(j.m, "")
+	return flat
+}
+
+// NewJsonMapFlat creates a new JsonMapper by rebuilding the nested structure described by a flat map
+// of keyPaths to values, the inverse of Flatten.
+// Both dot and bracket notation are accepted; numeric segments such as "[0]" or ".0" create arrays,
+// and any missing array elements before the highest index are filled with nil.
+// Returns an error if two paths conflict, e.g. "a" holding a scalar while "a.b" is also given.
+func NewJsonMapFlat(flat map[string]interface{}) (*JsonMapper, error) {
+	paths := make([]string, 0, len(flat))
+	for k := range flat {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+
+	m := make(map[string]interface{})
+	for _, path := range paths {
+		keys := splitKeyPath(path)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w: empty", ErrInvalidPath)
+		}
+		if _, err := unflattenInsert(m, keys, deepCopy(flat[path]), path); err != nil {
+			return nil, err
+		}
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// unflattenInsert stores value at keys below node, creating maps for object keys and slices for numeric keys.
+// Returns the (possibly new) node that should replace the given one in its parent.
+func unflattenInsert(node interface{}, keys []string, value interface{}, path string) (interface{}, error) {
+	key := keys[0]
+	last := len(keys) == 1
+
+	if index, err := strconv.Atoi(key); err == nil {
+		if index < 0 {
+			return nil, fmt.Errorf("%w: invalid array index '%s' in %s", ErrInvalidPath, key, path)
+		}
+		s, ok := node.([]interface{})
+		if !ok && node != nil {
+			return nil, fmt.Errorf("conflicting path: %s", path)
+		}
+		for len(s) <= index {
+			s = append(s, nil)
+		}
+		child, err := unflattenChild(s[index], keys, value, path)
+		if err != nil {
+			return nil, err
+		}
+		s[index] = child
+		return s, nil
+	}
+
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		if node != nil {
+			return nil, fmt.Errorf("conflicting path: %s", path)
+		}
+		m = make(map[string]interface{})
+	}
+	if _, exists := m[key]; last && exists {
+		return nil, fmt.Errorf("conflicting path: %s", path)
+	}
+	child, err := unflattenChild(m[key], keys, value, path)
+	if err != nil {
+		return nil, err
+	}
+	m[key] = child
+	return m, nil
+}
+
+// unflattenChild returns the value to store for keys[0]: either the leaf value or the rebuilt subtree.
+func unflattenChild(existing interface{}, keys []string, value interface{}, path string) (interface{}, error) {
+	if len(keys) == 1 {
+		if existing != nil {
+			return nil, fmt.Errorf("conflicting path: %s", path)
+		}
+		return value, nil
+	}
+	return unflattenInsert(existing, keys[1:], value, path)
+}
@@ -0,0 +1,43 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": {"b": [1, {"c": "x"}], "e": {}}, "d": null}`)
+	flat := j.Flatten()
+
+	want := map[string]interface{}{
+		"a.b[0]":   1.0,
+		"a.b[1].c": "x",
+		"d":        nil,
+	}
+	if len(flat) != len(want)+1 {
+		t.Fatalf("unexpected entries: %v", flat)
+	}
+	for k, v := range want {
+		if got, ok := flat[k]; !ok || got != v {
+			t.Errorf("%s: got %v, want %v", k, got, v)
+		}
+	}
+	if e, ok := flat["a.e"].(map[string]interface{}); !ok || len(e) != 0 {
+		t.Errorf("a.e: expected empty object, got %v", flat["a.e"])
+	}
+}
+
+func TestNewJsonMapFlat(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	rebuilt, err := NewJsonMapFlat(j.Flatten())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rebuilt.Equal(j) {
+		t.Errorf("round trip mismatch:\n%s\n%s", rebuilt.Print(), j.Print())
+	}
+
+	if _, err := NewJsonMapFlat(map[string]interface{}{"a": 1, "a.b": 2}); err == nil {
+		t.Error("expected conflicting paths to fail")
+	}
+}
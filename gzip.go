@@ -0,0 +1,51 @@
+package jsonmapper_v2
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+)
+
+// NewJsonMapFileGzip initializes a new JsonMapper instance from a gzip-compressed JSON file (e.g. dump.json.gz).
+// The file is decompressed while it is parsed, so the uncompressed content is never held in memory as a whole.
+// Options such as UseNumber control how the JSON is decoded.
+// Returns an error if the file cannot be read, is not valid gzip, or parsing the JSON fails.
+func NewJsonMapFileGzip(filePath string, opts ...Option) (*JsonMapper, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return NewJsonMapReader(zr, opts...)
+}
+
+// WriteFileGzip saves the current JSON structure to a gzip-compressed file,
+// with an option to format the output with indentation.
+// Returns an error if encoding, compressing or writing the file fails.
+func (j *JsonMapper) WriteFileGzip(filePath string, pretty bool) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	if err := j.WriteJSON(zw, pretty); err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	path := filepath.Join(t.TempDir(), "dump.json.gz")
+
+	for _, pretty := range []bool{false, true} {
+		if err := j.WriteFileGzip(path, pretty); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := NewJsonMapFileGzip(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.Print() != j.Print() {
+			t.Errorf("pretty=%v: got %s, want %s", pretty, loaded.Print(), j.Print())
+		}
+	}
+
+	plain := filepath.Join(t.TempDir(), "plain.json")
+	os.WriteFile(plain, []byte(test_nested_json_string), 0644)
+	if _, err := NewJsonMapFileGzip(plain); err == nil {
+		t.Error("expected error for an uncompressed file")
+	}
+}
@@ -0,0 +1,56 @@
+package jsonmapper_v2
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// HashPaths replaces the values at the given paths with their hex-encoded digests, for pseudonymizing
+// personal data before exporting it: equal values still yield equal digests, so records can be joined.
+// algo is one of "sha256", "sha512", "sha1" or "md5" (case-insensitive). Strings are hashed as is,
+// any other value as its JSON encoding. Paths follow the same rules as Redact, including "*" wildcards;
+// paths that do not exist are skipped.
+// Returns an error if algo is unsupported or a path is empty.
+func (j *JsonMapper) HashPaths(paths []string, algo string) error {
+	newHash, err := hashFunc(algo)
+	if err != nil {
+		return err
+	}
+
+	return j.rewritePaths("hash", paths, func(value interface{}) (interface{}, error) {
+		data, ok := value.(string)
+		if !ok {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			data = string(encoded)
+		}
+		h := newHash()
+		h.Write([]byte(data))
+		return hex.EncodeToString(h.Sum(nil)), nil
+	})
+}
+
+// hashFunc returns the constructor of the named hash algorithm.
+func hashFunc(algo string) (func() hash.Hash, error) {
+	switch strings.ToLower(algo) {
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "md5":
+		return md5.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", algo)
+	}
+}
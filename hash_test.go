@@ -0,0 +1,34 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestHashPaths(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"users": [{"email": "a@example.com", "id": 1}, {"email": "b@example.com", "id": 2}], "name": "abc"}`)
+
+	if err := j.HashPaths([]string{"users[*].email", "users[0].id"}, "SHA256"); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := j.FindString("users[0].email")
+	second, _ := j.FindString("users[1].email")
+	if len(first) != 64 || len(second) != 64 || first == second {
+		t.Errorf("emails = %q, %q", first, second)
+	}
+	// sha256("1")
+	if got, _ := j.FindString("users[0].id"); got != "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b" {
+		t.Errorf("users[0].id = %q", got)
+	}
+	if got, _ := j.FindInt("users[1].id"); got != 2 {
+		t.Errorf("users[1].id = %d", got)
+	}
+
+	if err := j.HashPaths([]string{"name"}, "md5"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := j.FindString("name"); got != "900150983cd24fb0d6963f7d28e17f72" {
+		t.Errorf("name = %q", got)
+	}
+
+	if err := j.HashPaths([]string{"name"}, "crc32"); err == nil {
+		t.Error("expected error for an unsupported algorithm")
+	}
+}
@@ -0,0 +1,116 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+)
+
+// HistoryEntry describes a single mutation recorded in the undo history.
+// Op is the name of the operation (e.g. "add" or "remove"), Path is the keyPath it was applied to
+// and Value is the value that was written, if any.
+type HistoryEntry struct {
+	Op    string
+	Path  string
+	Value interface{}
+}
+
+// history keeps the undo and redo stacks of a JsonMapper.
+// Each record stores a snapshot of the document taken before the mutation,
+// which keeps Undo exact regardless of how the mutation reshaped maps and slices.
+type history struct {
+	limit int
+	undo  []historyRecord
+	redo  []historyRecord
+}
+
+type historyRecord struct {
+	entry    HistoryEntry
+	snapshot map[string]interface{}
+}
+
+// EnableHistory starts recording mutations so they can be reverted with Undo and reapplied with Redo.
+// limit caps the number of undoable mutations kept in memory; the oldest entries are discarded first.
+// A limit of zero or less keeps every mutation.
+//
+// Note: every recorded mutation stores a deep copy of the document,
+// so a limit should be set when working with large documents.
+func (j *JsonMapper) EnableHistory(limit int) {
+	if j.history == nil {
+		j.history = &history{}
+	}
+	j.history.limit = limit
+	j.history.trim()
+}
+
+// DisableHistory stops recording mutations and discards the recorded history.
+func (j *JsonMapper) DisableHistory() {
+	j.history = nil
+}
+
+// History returns the mutations that can currently be undone, oldest first.
+func (j *JsonMapper) History() []HistoryEntry {
+	if j.history == nil {
+		return nil
+	}
+	entries := make([]HistoryEntry, len(j.history.undo))
+	for i, r := range j.history.undo {
+		entries[i] = r.entry
+	}
+	return entries
+}
+
+// Undo reverts the most recent recorded mutation.
+// Returns an error if history is not enabled or there is nothing to undo.
+func (j *JsonMapper) Undo() error {
+	if j.history == nil {
+		return fmt.Errorf("history is not enabled")
+	}
+	if len(j.history.undo) == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+
+	last := len(j.history.undo) - 1
+	r := j.history.undo[last]
+	j.history.undo = j.history.undo[:last]
+	prev := j.m
+	j.history.redo = append(j.history.redo, historyRecord{entry: r.entry, snapshot: prev})
+	j.m = r.snapshot
+	j.invalidatePathCache()
+	j.notify("undo", "", prev, j.m)
+	return nil
+}
+
+// Redo reapplies the most recently undone mutation.
+// Returns an error if history is not enabled or there is nothing to redo.
+// Any new mutation made after an Undo clears the redo stack.
+func (j *JsonMapper) Redo() error {
+	if j.history == nil {
+		return fmt.Errorf("history is not enabled")
+	}
+	if len(j.history.redo) == 0 {
+		return fmt.Errorf("nothing to redo")
+	}
+
+	last := len(j.history.redo) - 1
+	r := j.history.redo[last]
+	j.history.redo = j.history.redo[:last]
+	prev := j.m
+	j.history.undo = append(j.history.undo, historyRecord{entry: r.entry, snapshot: prev})
+	j.m = r.snapshot
+	j.invalidatePathCache()
+	j.notify("redo", "", prev, j.m)
+	return nil
+}
+
+// record pushes a completed mutation onto the undo stack and clears the redo stack.
+func (h *history) record(entry HistoryEntry, snapshot map[string]interface{}) {
+	h.undo = append(h.undo, historyRecord{entry: entry, snapshot: snapshot})
+	h.redo = nil
+	h.trim()
+}
+
+// trim drops the oldest undo records beyond the configured limit.
+func (h *history) trim() {
+	if h.limit > 0 && len(h.undo) > h.limit {
+		h.undo = append([]historyRecord(nil), h.undo[len(h.undo)-h.limit:]...)
+	}
+}
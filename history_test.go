@@ -0,0 +1,53 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+)
+
+func TestUndoRedo(t *testing.T) {
+	j, err := NewJsonMapStr(test_nested_json_string)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.EnableHistory(0)
+
+	if err := j.Add("testData.number", 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Remove("testData.sliced[0]"); err != nil {
+		t.Fatal(err)
+	}
+	if h := j.History(); len(h) != 2 || h[0].Op != "add" || h[1].Op != "remove" {
+		t.Fatalf("unexpected history: %v", h)
+	}
+
+	if err := j.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := j.FindSlice("testData.sliced"); len(s) != 5 {
+		t.Errorf("undo remove: got %v", s)
+	}
+	if err := j.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if v := j.FindIntOr("testData.number", -1); v != 25 {
+		t.Errorf("undo add: got %d", v)
+	}
+	if err := j.Undo(); err == nil {
+		t.Error("expected error when nothing to undo")
+	}
+
+	if err := j.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := j.Find("testData.number"); v != 30 {
+		t.Errorf("redo add: got %v", v)
+	}
+
+	if err := j.Add("testData.string", "bye"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Redo(); err == nil {
+		t.Error("expected redo stack to be cleared by a new mutation")
+	}
+}
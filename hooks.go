@@ -0,0 +1,23 @@
+package jsonmapper_v2
+
+// ChangeFunc is called after a mutation has been applied to the document.
+// op names the operation (e.g. "add", "set", "remove"), path is the keyPath it was applied to,
+// and oldValue/newValue hold the value at that path before and after the change.
+// oldValue is nil when the path did not exist, and newValue is nil after a removal.
+// For operations that relocate a value, such as "rename", "move" and "copy", newValue describes the destination instead.
+// Operations that replace the whole document, such as "commit", "undo" and "redo",
+// are reported with an empty path and the previous and current root objects.
+type ChangeFunc func(op, path string, oldValue, newValue interface{})
+
+// OnChange registers fn to be called after every successful mutation of the document.
+// Callbacks are invoked synchronously in registration order and must not mutate the JsonMapper.
+func (j *JsonMapper) OnChange(fn ChangeFunc) {
+	j.hooks = append(j.hooks, fn)
+}
+
+// notify invokes all registered change callbacks.
+func (j *JsonMapper) notify(op, path string, oldValue, newValue interface{}) {
+	for _, fn := range j.hooks {
+		fn(op, path, oldValue, newValue)
+	}
+}
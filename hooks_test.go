@@ -0,0 +1,111 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOnChange(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	var ops []string
+	j.OnChange(func(op, path string, oldValue, newValue interface{}) {
+		ops = append(ops, op+" "+path)
+		if op == "set" && (oldValue != 25.0 || newValue != 26) {
+			t.Errorf("set: got old %v new %v", oldValue, newValue)
+		}
+	})
+
+	_ = j.Set("testData.number", 26)
+	_ = j.Remove("testData.bool")
+	_ = j.Set("testData.missing", 1)
+
+	if len(ops) != 2 || ops[0] != "set testData.number" || ops[1] != "remove testData.bool" {
+		t.Errorf("unexpected notifications: %v", ops)
+	}
+}
+
+// rootChange runs fn on a mapper created from doc and returns the JSON of the oldValue and newValue
+// reported for the whole-document operation op, as seen by the callback when it was called.
+func rootChange(t *testing.T, doc, op string, fn func(j *JsonMapper) error) (oldJSON, newJSON string) {
+	t.Helper()
+	j, err := NewJsonMapStr(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	j.OnChange(func(gotOp, path string, oldValue, newValue interface{}) {
+		calls++
+		if gotOp != op || path != "" {
+			t.Errorf("notification = %s %q, want %s with an empty path", gotOp, path, op)
+		}
+		oldData, _ := json.Marshal(oldValue)
+		newData, _ := json.Marshal(newValue)
+		oldJSON, newJSON = string(oldData), string(newData)
+	})
+	if err := fn(j); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("%s notified %d times, want 1", op, calls)
+	}
+	return oldJSON, newJSON
+}
+
+func TestOnChangeWholeDocument(t *testing.T) {
+	for _, tt := range []struct {
+		op       string
+		fn       func(j *JsonMapper) error
+		old, new string
+	}{
+		{"commit", func(j *JsonMapper) error {
+			tx := j.Begin()
+			_ = tx.Set("a", 2)
+			return tx.Commit()
+		}, `{"a":1}`, `{"a":2}`},
+		{"apply", func(j *JsonMapper) error {
+			return j.Apply([]Op{{Action: "add", Path: "b", Value: true}})
+		}, `{"a":1}`, `{"a":1,"b":true}`},
+	} {
+		old, new := rootChange(t, `{"a": 1}`, tt.op, tt.fn)
+		if old != tt.old || new != tt.new {
+			t.Errorf("%s: old %s new %s, want old %s new %s", tt.op, old, new, tt.old, tt.new)
+		}
+	}
+}
+
+func TestOnChangeMerge(t *testing.T) {
+	old, new := rootChange(t, `{"a": 1}`, "merge", func(j *JsonMapper) error {
+		other, _ := NewJsonMapStr(`{"b": 2}`)
+		return j.Merge(other, MergeReplace)
+	})
+	if old != `{"a":1}` || new != `{"a":1,"b":2}` {
+		t.Errorf("old %s new %s", old, new)
+	}
+}
+
+func TestOnChangeExpandEnv(t *testing.T) {
+	t.Setenv("HOOK_TEST_HOST", "db.local")
+	old, new := rootChange(t, `{"host": "${HOOK_TEST_HOST}"}`, "expandEnv", (*JsonMapper).ExpandEnv)
+	if old != `{"host":"${HOOK_TEST_HOST}"}` || new != `{"host":"db.local"}` {
+		t.Errorf("old %s new %s", old, new)
+	}
+}
+
+func TestOnChangeRedactKeys(t *testing.T) {
+	old, new := rootChange(t, `{"user": {"password": "hunter2"}}`, "redact", func(j *JsonMapper) error {
+		return j.RedactKeys("password", "***")
+	})
+	if old != `{"user":{"password":"hunter2"}}` || new != `{"user":{"password":"***"}}` {
+		t.Errorf("old %s new %s", old, new)
+	}
+}
+
+func TestOnChangeCompact(t *testing.T) {
+	old, new := rootChange(t, `{"a": null, "b": 1}`, "compact", func(j *JsonMapper) error {
+		return j.Compact(true, true, true)
+	})
+	if old != `{"a":null,"b":1}` || new != `{"b":1}` {
+		t.Errorf("old %s new %s", old, new)
+	}
+}
@@ -0,0 +1,179 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Keys returns the names of the direct children of the value at keyPath.
+// For an object the key names are returned in sorted order; for an array the stringified indexes
+// ("0", "1", ...) are returned. An empty keyPath refers to the root object.
+// Returns an error if the path does not exist or the value is neither an object nor an array.
+func (j *JsonMapper) Keys(keyPath string) ([]string, error) {
+	value, err := j.Find(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return sortedKeys(v), nil
+	case []interface{}:
+		keys := make([]string, len(v))
+		for i := range v {
+			keys[i] = strconv.Itoa(i)
+		}
+		return keys, nil
+	default:
+		return nil, fmt.Errorf("%w: value at %s is not an object or array", ErrTypeMismatch, keyPath)
+	}
+}
+
+// Paths returns the keyPath of every leaf value in the document, in the order visited by Walk.
+// Leaves are scalar values (including null) as well as empty objects and arrays.
+// Paths use the same notation as Flatten and FindAllWithCondition.
+func (j *JsonMapper) Paths() []string {
+	var paths []string
+	_ = j.Walk(func(path string, value interface{}) (bool, error) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 && path != "" {
+				paths = append(paths, path)
+			}
+			return true, nil
+		case []interface{}:
+			if len(v) == 0 {
+				paths = append(paths, path)
+			}
+			return true, nil
+		default:
+			paths = append(paths, path)
+			return false, nil
+		}
+	})
+	return paths
+}
+
+// Len returns the size of the value at keyPath without copying it:
+// the number of elements of an array, the number of keys of an object,
+// or the number of characters (runes) of a string.
+// Returns an error if the path does not exist or the value has no length.
+func (j *JsonMapper) Len(keyPath string) (int, error) {
+	value, err := j.Find(keyPath)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return len(v), nil
+	case []interface{}:
+		return len(v), nil
+	case string:
+		return utf8.RuneCountInString(v), nil
+	default:
+		return 0, fmt.Errorf("%w: value at %s has no length", ErrTypeMismatch, keyPath)
+	}
+}
+
+// Exists reports whether keyPath resolves to a value in the document.
+// A key that is present with a null value exists, whereas a missing key,
+// an out-of-range index, or a path continuing below a scalar value does not.
+// Like Find, it consults the fallback mapper set with WithFallback.
+func (j *JsonMapper) Exists(keyPath string) bool {
+	_, ok := j.lookupWithFallback(keyPath)
+	return ok
+}
+
+// RequirePaths verifies that every given keyPath exists and is not null, as a sanity check of
+// configuration after loading. All failing paths are collected, so a single error reports everything
+// that needs fixing, e.g. "missing required paths: db.host, db.port". Returns nil if all paths are present.
+// Paths provided by the fallback mapper set with WithFallback count as present.
+func (j *JsonMapper) RequirePaths(paths ...string) error {
+	var missing []string
+	for _, path := range paths {
+		value, ok := j.lookupWithFallback(path)
+		if !ok || expandLazy(value) == nil {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required paths: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Stats holds size metrics of a document, as returned by JsonMapper.Stats.
+type Stats struct {
+	// MaxDepth is the deepest level of object/array nesting; the root object is at depth 1.
+	MaxDepth int
+	// Nodes is the total number of values in the document, including the root and all containers.
+	Nodes int
+	// Arrays is the number of arrays in the document.
+	Arrays int
+	// Objects is the number of objects in the document, including the root.
+	Objects int
+	// Bytes is the approximate size of the document serialized as compact JSON.
+	// String escaping is not taken into account.
+	Bytes int
+}
+
+// Stats computes size metrics of the whole document in a single traversal without serializing it.
+// Services can use it to reject pathological payloads, e.g. ones that are nested too deeply.
+func (j *JsonMapper) Stats() Stats {
+	j.materialize()
+	var stats Stats
+
+	var measure func(value interface{}, depth int)
+	measure = func(value interface{}, depth int) {
+		stats.Nodes++
+		switch v := value.(type) {
+		case map[string]interface{}:
+			stats.Objects++
+			if depth > stats.MaxDepth {
+				stats.MaxDepth = depth
+			}
+			stats.Bytes += 2 + separators(len(v))
+			for k, child := range v {
+				stats.Bytes += len(k) + 3
+				measure(child, depth+1)
+			}
+		case []interface{}:
+			stats.Arrays++
+			if depth > stats.MaxDepth {
+				stats.MaxDepth = depth
+			}
+			stats.Bytes += 2 + separators(len(v))
+			for _, child := range v {
+				measure(child, depth+1)
+			}
+		case string:
+			stats.Bytes += len(v) + 2
+		case float64:
+			stats.Bytes += len(strconv.FormatFloat(v, 'g', -1, 64))
+		case bool:
+			if v {
+				stats.Bytes += 4
+			} else {
+				stats.Bytes += 5
+			}
+		case nil:
+			stats.Bytes += 4
+		default:
+			stats.Bytes += len(fmt.Sprint(v))
+		}
+	}
+
+	measure(j.m, 1)
+	return stats
+}
+
+// separators returns the number of commas needed between n elements.
+func separators(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return n - 1
+}
@@ -0,0 +1,109 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	keys, err := j.Keys("testData.nested")
+	if err != nil || !reflect.DeepEqual(keys, []string{"number", "string"}) {
+		t.Errorf("object keys: got %v, %v", keys, err)
+	}
+	keys, err = j.Keys("testData.s2")
+	if err != nil || !reflect.DeepEqual(keys, []string{"0", "1", "2"}) {
+		t.Errorf("array keys: got %v, %v", keys, err)
+	}
+	if _, err := j.Keys("testData.string"); err == nil {
+		t.Error("expected error for scalar value")
+	}
+}
+
+func TestPaths(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"b": {"y": null, "x": []}, "a": [1, {"c": true}]}`)
+
+	want := []string{"a[0]", "a[1].c", "b.x", "b.y"}
+	if got := j.Paths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLen(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": [1, 2, 3], "o": {"x": 1}, "s": "héllo", "n": 1}`)
+
+	for path, want := range map[string]int{"a": 3, "o": 1, "s": 5, "": 4} {
+		if got, err := j.Len(path); err != nil || got != want {
+			t.Errorf("%q: got %d, %v, want %d", path, got, err, want)
+		}
+	}
+	if _, err := j.Len("n"); err == nil {
+		t.Error("expected error for number")
+	}
+}
+
+func TestStats(t *testing.T) {
+	data := `{"a":[1,{"b":"xy"}],"c":null,"d":true}`
+	j, _ := NewJsonMapStr(data)
+
+	stats := j.Stats()
+	want := Stats{MaxDepth: 3, Nodes: 7, Arrays: 1, Objects: 2, Bytes: len(data)}
+	if stats != want {
+		t.Errorf("got %+v, want %+v", stats, want)
+	}
+}
+
+func TestExists(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": {"n": null, "s": "x"}, "l": [1]}`)
+
+	for path, want := range map[string]bool{
+		"":      true,
+		"a.n":   true,
+		"a.s":   true,
+		"l[0]":  true,
+		"a.m":   false,
+		"a.s.x": false,
+		"l[1]":  false,
+		"l.x":   false,
+	} {
+		if got := j.Exists(path); got != want {
+			t.Errorf("%q: got %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"o": {}, "a": [], "s": "", "n": 1, "b": false, "z": null}`)
+	_ = j.Add("i", 5)
+
+	for path, want := range map[string]Kind{
+		"":  KindObject,
+		"o": KindObject,
+		"a": KindArray,
+		"s": KindString,
+		"n": KindNumber,
+		"i": KindNumber,
+		"b": KindBool,
+		"z": KindNull,
+	} {
+		if got, err := j.TypeOf(path); err != nil || got != want {
+			t.Errorf("%q: got %v, %v, want %v", path, got, err, want)
+		}
+	}
+	if _, err := j.TypeOf("missing"); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
+
+func TestRequirePaths(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"db": {"host": "localhost", "port": null}, "tags": ["a"]}`)
+
+	if err := j.RequirePaths("db.host", "tags[0]"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := j.RequirePaths("db.host", "db.port", "db.user", "tags[1]")
+	if err == nil || err.Error() != "missing required paths: db.port, db.user, tags[1]" {
+		t.Errorf("err = %v", err)
+	}
+}
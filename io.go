@@ -0,0 +1,61 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"io"
+)
+
+// NewJsonMapReader initializes a new JsonMapper instance from JSON read from r,
+// such as an HTTP request body, a pipe or a socket, without buffering it into a string first.
+// Only the first JSON value is read; r is not closed. Options such as UseNumber control how the JSON is decoded.
+// With Lenient, Lazy, the duplicate key options or limits such as MaxDepth, the whole input is read before it is parsed;
+// with MaxBytes, reading stops once the limit is exceeded.
+// Returns an error if reading or parsing fails or the value is not an object.
+func NewJsonMapReader(r io.Reader, opts ...Option) (*JsonMapper, error) {
+	o := resolveOptions(opts)
+	if o.buffered() {
+		if o.maxBytes > 0 {
+			r = io.LimitReader(r, int64(o.maxBytes)+1)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return newJsonMap(data, o)
+	}
+
+	var m map[string]interface{}
+	if err := newDecoder(r, o).Decode(&m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, fmt.Errorf("JSON document is not an object")
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// WriteJSON writes the current JSON structure to w, indented with two spaces if pretty is true.
+// The output ends with a newline and honors the options set with SetPrintOptions.
+func (j *JsonMapper) WriteJSON(w io.Writer, pretty bool) error {
+	return j.encodeJSON(w, j.printOptions, pretty)
+}
+
+// WriteTo writes the current JSON structure to w in compact form, followed by a newline,
+// and returns the number of bytes written. It implements io.WriterTo; use WriteJSON for indented output.
+func (j *JsonMapper) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	err := j.WriteJSON(counter, false)
+	return counter.n, err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,37 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReaderWriter(t *testing.T) {
+	j, err := NewJsonMapReader(strings.NewReader(test_nested_json_string))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	n, err := j.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := j.Print() + "\n"; buf.String() != want || n != int64(len(want)) {
+		t.Errorf("WriteTo wrote %d bytes %q, want %q", n, buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := j.WriteJSON(&buf, true); err != nil {
+		t.Fatal(err)
+	}
+	if want := j.PrettyPrint() + "\n"; buf.String() != want {
+		t.Errorf("WriteJSON pretty: got %q, want %q", buf.String(), want)
+	}
+
+	for _, input := range []string{`[1, 2]`, `{"a":`, ``} {
+		if _, err := NewJsonMapReader(strings.NewReader(input)); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
@@ -5,9 +5,11 @@ package jsonmapper_v2
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -15,47 +17,45 @@ import (
 // JsonMapper is a struct that implements the JsonMapper interface.
 // It is used for manipulating JSON structures.
 type JsonMapper struct {
-	m map[string]interface{}
+	m        map[string]interface{}
+	history  *history
+	hooks    []ChangeFunc
+	lazy     bool
+	coerce   bool
+	fallback *JsonMapper
+	cache    *pathCache
+
+	printOptions PrintOptions
 }
 
 // NewJsonMapFromFile initializes a new JsonMapper instance from a JSON file.
 // It reads the file, unmarshals its content into a map[string]interface{}, and returns a new JsonMapper instance for manipulation.
 // Returns an error if reading the file or parsing the JSON fails.
-func NewJsonMapStr(s string) (*JsonMapper, error) {
-	var m map[string]interface{}
-	if err := json.Unmarshal([]byte(s), &m); err != nil {
-		return nil, err
-	}
-	return &JsonMapper{m: m}, nil
+// Options such as UseNumber control how the JSON is decoded.
+func NewJsonMapStr(s string, opts ...Option) (*JsonMapper, error) {
+	return newJsonMap([]byte(s), resolveOptions(opts))
 }
 
 // NewJsonMapFromFile initializes a new JsonMapper instance from a JSON file.
 // It reads the file, unmarshals its content into a map[string]interface{}, and returns a new JsonMapper instance for manipulation.
 // Returns an error if reading the file or parsing the JSON fails.
-func NewJsonMapFile(filePath string) (*JsonMapper, error) {
+// Options such as UseNumber control how the JSON is decoded.
+func NewJsonMapFile(filePath string, opts ...Option) (*JsonMapper, error) {
 	byteValue, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	var m map[string]interface{}
-	if err := json.Unmarshal(byteValue, &m); err != nil {
-		return nil, err
-	}
-
-	return &JsonMapper{m: m}, nil
+	return newJsonMap(byteValue, resolveOptions(opts))
 }
 
 // NewJsonMapFromBytes initializes a new JsonMapper instance from a slice of bytes containing JSON data.
 // It unmarshals the byte slice into a map[string]interface{} for manipulation.
 // Useful for processing JSON data received from APIs or other byte streams.
+// Options such as UseNumber control how the JSON is decoded.
 // Returns an error if unmarshaling fails.
-func NewJsonMapBytes(data []byte) (*JsonMapper, error) {
-	var m map[string]interface{}
-	if err := json.Unmarshal(data, &m); err != nil {
-		return nil, err
-	}
-	return &JsonMapper{m: m}, nil
+func NewJsonMapBytes(data []byte, opts ...Option) (*JsonMapper, error) {
+	return newJsonMap(data, resolveOptions(opts))
 }
 
 // NewJsonMapObject creates a new JsonMapper instance from an arbitrary object.
@@ -65,6 +65,7 @@ func NewJsonMapBytes(data []byte) (*JsonMapper, error) {
 //
 // Parameters:
 // - o: The input object to be converted into a map. This can be of any type.
+// - opts: Options such as UseNumber controlling how the marshaled JSON is decoded.
 //
 // Returns:
 // - A pointer to a newly created JsonMapper instance containing the map, or nil if an error occurs.
@@ -72,14 +73,14 @@ func NewJsonMapBytes(data []byte) (*JsonMapper, error) {
 //
 // Note: This function may not be efficient for large objects or in performance-critical code paths,
 // as it involves marshaling and unmarshaling of JSON data. Consider alternative approaches if this is a concern.
-func NewJsonMapObject(o interface{}) (*JsonMapper, error) {
+func NewJsonMapObject(o interface{}, opts ...Option) (*JsonMapper, error) {
 	m, ok := o.(map[string]interface{})
 	if !ok {
 		buffer, err := json.Marshal(o)
 		if err != nil {
 			return nil, err
 		}
-		if err := json.Unmarshal(buffer, &m); err != nil {
+		if m, err = decodeObject(buffer, resolveOptions(opts)); err != nil {
 			return nil, err
 		}
 	}
@@ -90,38 +91,183 @@ func NewJsonMapObject(o interface{}) (*JsonMapper, error) {
 // The keyPath is a dot-separated string indicating the path to the value.
 // Supports array indexing using the notation [index] or .index.
 // Returns the value as an interface{} or an error if the path is invalid or the key does not exist.
+// If the path cannot be resolved and a fallback mapper is set (see WithFallback), the fallback is consulted instead.
 func (j *JsonMapper) Find(keyPath string) (interface{}, error) {
+	value, err := j.cachedFind(keyPath)
+	if err != nil && j.fallback != nil {
+		if fallbackValue, fallbackErr := j.fallback.Find(keyPath); fallbackErr == nil {
+			return fallbackValue, nil
+		}
+	}
+	return value, err
+}
+
+// cachedFind is find with the path cache enabled by EnablePathCache consulted first.
+func (j *JsonMapper) cachedFind(keyPath string) (interface{}, error) {
+	value, miss := j.cachedResolve(keyPath)
+	if miss.err != nil {
+		return nil, miss.pathError(keyPath)
+	}
+	return value, nil
+}
+
+// lookup is Find without building an error for a path that cannot be resolved, for callers that only need
+// to know whether it resolves, such as the Find<Type>Or variants. It consults the path cache and the fallback.
+func (j *JsonMapper) lookup(keyPath string) (interface{}, bool) {
+	value, miss := j.cachedResolve(keyPath)
+	if miss.err == nil {
+		return value, true
+	}
+	if j.fallback != nil {
+		return j.fallback.lookup(keyPath)
+	}
+	return nil, false
+}
+
+// cachedResolve is resolve with the path cache enabled by EnablePathCache consulted first.
+func (j *JsonMapper) cachedResolve(keyPath string) (interface{}, findMiss) {
+	if j.cache == nil {
+		return j.resolve(keyPath)
+	}
+	if value, ok := j.cache.get(keyPath); ok {
+		return value, findMiss{}
+	}
+	value, miss := j.resolve(keyPath)
+	if miss.err == nil {
+		j.cache.put(keyPath, value)
+	}
+	return value, miss
+}
+
+// find implements Find on this document only, without consulting the fallback.
+func (j *JsonMapper) find(keyPath string) (interface{}, error) {
+	value, miss := j.resolve(keyPath)
+	if miss.err != nil {
+		return nil, miss.pathError(keyPath)
+	}
+	return value, nil
+}
+
+// findKeys resolves the already split segments of keyPath. keyPath is only used in errors.
+func (j *JsonMapper) findKeys(keyPath string, keys []string) (interface{}, error) {
+	value, miss := j.resolveKeys(keys)
+	if miss.err != nil {
+		return nil, miss.pathError(keyPath)
+	}
+	return value, nil
+}
+
+// resolve looks up keyPath in this document and describes a failure with a findMiss instead of an error,
+// so lookups that fail do not allocate either.
+// Paths whose segments are plain substrings of keyPath, which includes every path written with dots
+// and trailing bracket indexes, are resolved without splitting keyPath, so reading a value does not allocate.
+func (j *JsonMapper) resolve(keyPath string) (interface{}, findMiss) {
 	if keyPath == "" {
-		return j.m, nil
+		return j.found(j.m), findMiss{}
+	}
+	if !contiguousSegments(keyPath) {
+		return j.resolveKeys(splitKeyPath(keyPath))
 	}
 
-	convertedKeyPath := convertBracketsToDots(keyPath)
-	keys := strings.Split(convertedKeyPath, ".")
 	var current interface{} = j.m
+	scanner := segmentScanner{path: keyPath}
+	for i := 0; ; i++ {
+		key, ok := scanner.next()
+		if !ok {
+			break
+		}
+		next, stop, miss := findStep(current, key, i)
+		if miss.err != nil || stop {
+			return next, miss
+		}
+		current = next
+	}
+	return j.found(current), findMiss{}
+}
 
-	for _, key := range keys {
-		switch currentType := current.(type) {
-		case map[string]interface{}:
-			if value, ok := currentType[key]; ok {
-				current = value
-			} else {
-				return nil, fmt.Errorf("key not found: %s", key)
-			}
-		case []interface{}:
-			index, err := strconv.Atoi(key)
-			if err != nil {
-				return nil, fmt.Errorf("invalid array index: %s", key)
-			}
-			if index < 0 || index >= len(currentType) {
-				return nil, fmt.Errorf("array index out of range: %d", index)
-			}
-			current = currentType[index]
-		default:
-			return current, nil
+// resolveKeys is resolve for a path that has already been split into keys.
+func (j *JsonMapper) resolveKeys(keys []string) (interface{}, findMiss) {
+	if len(keys) == 0 {
+		return j.found(j.m), findMiss{}
+	}
+
+	var current interface{} = j.m
+	for i, key := range keys {
+		next, stop, miss := findStep(current, key, i)
+		if miss.err != nil || stop {
+			return next, miss
+		}
+		current = next
+	}
+	return j.found(current), findMiss{}
+}
+
+// findMiss describes why a path could not be resolved without allocating; the zero value means it was resolved.
+type findMiss struct {
+	// err is ErrKeyNotFound, ErrInvalidPath or ErrIndexOutOfRange, or nil if the path was resolved.
+	err error
+	// segment is the failing segment, at position index of the path, and node the value it was applied to.
+	segment string
+	index   int
+	node    interface{}
+	// arrayIndex is the parsed segment for ErrIndexOutOfRange.
+	arrayIndex int
+}
+
+// pathError builds the PathError Find returns for the miss while resolving keyPath.
+func (m findMiss) pathError(keyPath string) error {
+	var err error
+	switch m.err {
+	case ErrKeyNotFound:
+		err = fmt.Errorf("%w: %s", ErrKeyNotFound, m.segment)
+	case ErrIndexOutOfRange:
+		err = fmt.Errorf("%w: %d", ErrIndexOutOfRange, m.arrayIndex)
+	default:
+		err = fmt.Errorf("%w: invalid array index %s", ErrInvalidPath, m.segment)
+	}
+	return newPathError(keyPath, m.segment, m.index, m.node, err)
+}
+
+// findStep resolves the segment key, at position i of the path, against current and returns the child value,
+// decoding it first if it is an undecoded value of a lazy document.
+// stop is true if current is a scalar, in which case the lookup ends with current as its result.
+func findStep(current interface{}, key string, i int) (next interface{}, stop bool, miss findMiss) {
+	switch currentType := current.(type) {
+	case map[string]interface{}:
+		value, ok := currentType[key]
+		if !ok {
+			return nil, false, findMiss{err: ErrKeyNotFound, segment: key, index: i, node: current}
+		}
+		if isRaw(value) {
+			value = expandLazy(value)
+			currentType[key] = value
+		}
+		return value, false, findMiss{}
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, false, findMiss{err: ErrInvalidPath, segment: key, index: i, node: current}
 		}
+		if index < 0 || index >= len(currentType) {
+			return nil, false, findMiss{err: ErrIndexOutOfRange, segment: key, index: i, node: current, arrayIndex: index}
+		}
+		value := currentType[index]
+		if isRaw(value) {
+			value = expandLazy(value)
+			currentType[index] = value
+		}
+		return value, false, findMiss{}
+	default:
+		return current, true, findMiss{}
 	}
+}
 
-	return current, nil
+// found finishes a successful lookup, decoding the rest of the value in lazy documents.
+func (j *JsonMapper) found(value interface{}) interface{} {
+	if j.lazy {
+		materializeValue(value)
+	}
+	return value
 }
 
 // Add inserts or updates a value at the specified keyPath within the JSON structure.
@@ -130,58 +276,37 @@ func (j *JsonMapper) Find(keyPath string) (interface{}, error) {
 // Supports negative indexing with -1 to append to slices.
 // Returns an error if the path is invalid or if the operation cannot be completed.
 func (j *JsonMapper) Add(keyPath string, value interface{}) error {
-	convertedKeyPath := convertBracketsToDots(keyPath)
-	keys := strings.Split(convertedKeyPath, ".")
-	var current interface{} = j.m
+	return j.mutate("add", keyPath, value, func() error {
+		return j.add(keyPath, value)
+	})
+}
 
-	for i := 0; i < len(keys); i++ {
-		key := keys[i]
-		lastKey := i == len(keys)-1
-
-		if lastKey {
-			switch parent := current.(type) {
-			case map[string]interface{}:
-				parent[key] = value
-			case []interface{}:
-				index, err := strconv.Atoi(key)
-				if err != nil {
-					return fmt.Errorf("invalid array index '%s': %v", key, err)
-				}
-				if index == -1 {
-					current = append(parent, value)
-				} else if index >= 0 && index < len(parent) {
-					parent[index] = value
-				} else {
-					return fmt.Errorf("array index '%d' is out of range", index)
-				}
-
-				if i > 0 {
-					parentKey := keys[i-1]
-					grandParent, _ := j.m[keys[0]].(map[string]interface{})
-					for _, k := range keys[1 : i-1] {
-						grandParent = grandParent[k].(map[string]interface{})
-					}
-					grandParent[parentKey] = current
-				}
-			}
-			break
-		} else {
-			if next, ok := current.(map[string]interface{})[key]; ok {
-				current = next
-			} else if index, err := strconv.Atoi(key); err == nil {
-				if nextSlice, ok := current.([]interface{}); ok && index >= 0 && index < len(nextSlice) {
-					current = nextSlice[index]
-				} else {
-					return fmt.Errorf("invalid array index '%s': %v", key, err)
-				}
-			} else {
-				current.(map[string]interface{})[key] = make(map[string]interface{})
-				current = current.(map[string]interface{})[key]
-			}
-		}
-	}
+// add implements Add without recording the mutation.
+func (j *JsonMapper) add(keyPath string, value interface{}) error {
+	return j.edit(keyPath, true, func(old interface{}, exists bool) (interface{}, error) {
+		return value, nil
+	})
+}
 
-	return nil
+// Set replaces the value located at the specified keyPath within the JSON structure.
+// Unlike Add, Set never creates missing keys or appends to slices: the path must already exist,
+// and an index of -1 refers to the last element of a slice.
+// Paths through arrays of objects (e.g. "items[1].name") are supported.
+// Returns an error if the path is invalid or the key does not exist.
+func (j *JsonMapper) Set(keyPath string, value interface{}) error {
+	return j.mutate("set", keyPath, value, func() error {
+		return j.set(keyPath, value)
+	})
+}
+
+// set implements Set without recording the mutation.
+func (j *JsonMapper) set(keyPath string, value interface{}) error {
+	return j.edit(keyPath, false, func(old interface{}, exists bool) (interface{}, error) {
+		if !exists {
+			return nil, ErrKeyNotFound
+		}
+		return value, nil
+	})
 }
 
 // Remove deletes the value located at the specified keyPath within the JSON structure.
@@ -189,59 +314,58 @@ func (j *JsonMapper) Add(keyPath string, value interface{}) error {
 // Supports negative indexing with -1 to remove the last element of a slice.
 // Returns an error if the path is invalid or the key does not exist.
 func (j *JsonMapper) Remove(keyPath string) error {
-	convertedKeyPath := convertBracketsToDots(keyPath)
-	keys := strings.Split(convertedKeyPath, ".")
-	current := j.m
-	var parent map[string]interface{} = nil
-	var parentKey string
-
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			break
-		}
+	return j.mutate("remove", keyPath, nil, func() error {
+		return j.remove(keyPath)
+	})
+}
 
-		if i == len(keys)-2 {
-			parent = current
-			parentKey = key
+// remove implements Remove without recording the mutation.
+func (j *JsonMapper) remove(keyPath string) error {
+	return j.edit(keyPath, false, func(old interface{}, exists bool) (interface{}, error) {
+		if !exists {
+			return nil, ErrKeyNotFound
 		}
+		return removeValue, nil
+	})
+}
 
-		switch currentElement := current[key].(type) {
-		case map[string]interface{}:
-			current = currentElement
-		case []interface{}:
-			index, err := strconv.Atoi(keys[i+1])
-			if err == nil && index == -1 {
-				index = len(currentElement) - 1
-			}
-			if index < 0 || index >= len(currentElement) {
-				return fmt.Errorf("array index '%d' is out of range", index)
-			}
-			if i == len(keys)-2 {
-				updatedSlice := append(currentElement[:index], currentElement[index+1:]...)
-				current[parentKey] = updatedSlice
-				return nil
-			}
-			if nextElement, ok := currentElement[index].(map[string]interface{}); ok {
-				current = nextElement
-			} else {
-				return fmt.Errorf("expected a map at '%s', but found a different type", keys[i+1])
-			}
-		default:
-			return fmt.Errorf("unexpected type %T at '%s'", currentElement, key)
-		}
+// mutate runs fn as a single mutation of the document identified by op, keyPath and value.
+// Every exported method that modifies the document goes through mutate,
+// so bookkeeping such as the undo history and change callbacks is handled in one place.
+func (j *JsonMapper) mutate(op, keyPath string, value interface{}, fn func() error) error {
+	var before map[string]interface{}
+	if j.history != nil {
+		before, _ = deepCopy(j.m).(map[string]interface{})
+	}
+	var oldValue interface{}
+	if len(j.hooks) > 0 {
+		oldValue, _ = j.find(keyPath)
 	}
 
-	if parent != nil {
-		delete(parent, keys[len(keys)-1])
+	// Invalidate even if fn fails, since a failed edit may still have created intermediate objects.
+	err := fn()
+	j.invalidatePathCache()
+	if err != nil {
+		return err
 	}
 
+	if j.history != nil {
+		j.history.record(HistoryEntry{Op: op, Path: keyPath, Value: value}, before)
+	}
+	if len(j.hooks) > 0 {
+		newValue := value
+		if keyPath == "" {
+			newValue = j.m
+		}
+		j.notify(op, keyPath, oldValue, newValue)
+	}
 	return nil
 }
 
 // Print returns the JSON structure as a compact string.
-// Useful for logging or debugging purposes.
+// Useful for logging or debugging purposes. Object keys are written in sorted order (see SetPrintOptions).
 func (j *JsonMapper) Print() string {
-	jsonString, err := json.Marshal(j.m)
+	jsonString, err := j.marshalJSON(j.printOptions, false)
 	if err != nil {
 		return ""
 	}
@@ -250,9 +374,10 @@ func (j *JsonMapper) Print() string {
 }
 
 // PrettyPrint returns the JSON structure as a well-formatted string with indentation.
-// Enhances readability for logging or debugging.
+// Enhances readability for logging or debugging. The indentation defaults to two spaces
+// and can be changed with SetPrintOptions or per call with PrettyPrintWith.
 func (j *JsonMapper) PrettyPrint() string {
-	jsonString, err := json.MarshalIndent(j.m, "", "  ")
+	jsonString, err := j.marshalJSON(j.printOptions, true)
 	if err != nil {
 		return ""
 	}
@@ -271,19 +396,29 @@ func (j *JsonMapper) FindBool(k string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	return j.asBool(tmp, k)
+}
+
+// asBool converts the value found at k for FindBool.
+func (j *JsonMapper) asBool(tmp interface{}, k string) (bool, error) {
+	tmp = j.coerceBool(tmp)
 	if boolValue, ok := tmp.(bool); ok {
 		return boolValue, nil
 	}
-	return false, fmt.Errorf("value at %s is not a bool", k)
+	return false, fmt.Errorf("%w: value at %s is not a bool", ErrTypeMismatch, k)
 }
 
 // FindBoolOr is similar to FindBool but returns a defaultValue if the value is not found.
 func (j *JsonMapper) FindBoolOr(k string, defaultValue bool) bool {
-	boolValue, err := j.FindBool(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asBool(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return boolValue
+	return value
 }
 
 // FindString searches for a string value at the given keyPath.
@@ -293,19 +428,29 @@ func (j *JsonMapper) FindString(k string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return j.asString(tmp, k)
+}
+
+// asString converts the value found at k for FindString.
+func (j *JsonMapper) asString(tmp interface{}, k string) (string, error) {
+	tmp = j.coerceString(tmp)
 	if strValue, ok := tmp.(string); ok {
 		return strValue, nil
 	}
-	return "", fmt.Errorf("value at %s is not a string", k)
+	return "", fmt.Errorf("%w: value at %s is not a string", ErrTypeMismatch, k)
 }
 
 // FindStringOr is similar to FindString but returns the defaultValue if the value is not found or not a string.
 func (j *JsonMapper) FindStringOr(k string, defaultValue string) string {
-	strValue, err := j.FindString(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asString(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return strValue
+	return value
 }
 
 // FindInt searches for an integer value at the given keyPath.
@@ -315,19 +460,112 @@ func (j *JsonMapper) FindInt(k string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	if intValue, ok := tmp.(float64); ok {
+	return j.asInt(tmp, k)
+}
+
+// asInt converts the value found at k for FindInt.
+func (j *JsonMapper) asInt(tmp interface{}, k string) (int, error) {
+	tmp = j.coerceNumber(tmp)
+	if intValue, ok := toInt64(tmp); ok {
 		return int(intValue), nil
 	}
-	return 0, fmt.Errorf("value at %s is not an int", k)
+	return 0, fmt.Errorf("%w: value at %s is not an int", ErrTypeMismatch, k)
 }
 
 // FindIntOr is similar to FindInt but returns the defaultValue if the value is not found or not an integer.
 func (j *JsonMapper) FindIntOr(k string, defaultValue int) int {
-	intValue, err := j.FindInt(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asInt(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return intValue
+	return value
+}
+
+// FindInt64 searches for a 64-bit integer value at the given keyPath.
+// Unlike FindInt, it never truncates: it returns an error if the number has a fractional part
+// or does not fit in an int64. Combined with UseNumber, the exact digits are parsed,
+// so identifiers beyond 2^53 such as snowflake IDs are returned without loss.
+func (j *JsonMapper) FindInt64(k string) (int64, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return 0, err
+	}
+	return j.asInt64(tmp, k)
+}
+
+// asInt64 converts the value found at k for FindInt64.
+func (j *JsonMapper) asInt64(tmp interface{}, k string) (int64, error) {
+	tmp = j.coerceNumber(tmp)
+
+	i, err := exactInt64(tmp)
+	if err != nil {
+		return 0, fmt.Errorf("%w: value at %s %v", ErrTypeMismatch, k, err)
+	}
+	return i, nil
+}
+
+// FindInt64Or is similar to FindInt64 but returns the defaultValue if the value is not found or not a 64-bit integer.
+func (j *JsonMapper) FindInt64Or(k string, defaultValue int64) int64 {
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asInt64(tmp, k)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// bigFloatPrec is the precision, in bits, of the values returned by FindBigFloat.
+const bigFloatPrec = 256
+
+// FindBigInt searches for an integer at the given keyPath and returns it as an arbitrary-precision *big.Int.
+// Numbers decoded with UseNumber and numeric strings such as "123456789012345678901234567890" are parsed
+// from their exact digits. Returns an error if the value is not a number or has a fractional part.
+func (j *JsonMapper) FindBigInt(k string) (*big.Int, error) {
+	f, err := j.FindBigFloat(k)
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsInt() {
+		return nil, fmt.Errorf("%w: value at %s has a fractional part", ErrTypeMismatch, k)
+	}
+	i, _ := f.Int(nil)
+	return i, nil
+}
+
+// FindBigFloat searches for a number at the given keyPath and returns it as a *big.Float with 256 bits of precision.
+// Numbers decoded with UseNumber and numeric strings such as "0.1" are parsed from their exact digits,
+// so precision lost by float64 decoding can be avoided for financial payloads.
+// Returns an error if the value is neither a number nor a numeric string.
+func (j *JsonMapper) FindBigFloat(k string) (*big.Float, error) {
+	tmp, err := j.Find(k)
+	if err != nil {
+		return nil, err
+	}
+
+	var digits string
+	switch v := tmp.(type) {
+	case float64:
+		return new(big.Float).SetPrec(bigFloatPrec).SetFloat64(v), nil
+	case json.Number:
+		digits = string(v)
+	case string:
+		digits = v
+	default:
+		return nil, fmt.Errorf("%w: value at %s is not a number", ErrTypeMismatch, k)
+	}
+
+	f, _, err := big.ParseFloat(digits, 10, bigFloatPrec, big.ToNearestEven)
+	if err != nil || f.IsInf() {
+		return nil, fmt.Errorf("%w: value at %s is not a number", ErrTypeMismatch, k)
+	}
+	return f, nil
 }
 
 // FindFloat searches for a float value at the given keyPath.
@@ -337,19 +575,29 @@ func (j *JsonMapper) FindFloat(k string) (float64, error) {
 	if err != nil {
 		return 0.0, err
 	}
-	if floatValue, ok := tmp.(float64); ok {
+	return j.asFloat(tmp, k)
+}
+
+// asFloat converts the value found at k for FindFloat.
+func (j *JsonMapper) asFloat(tmp interface{}, k string) (float64, error) {
+	tmp = j.coerceNumber(tmp)
+	if floatValue, ok := toFloat64(tmp); ok {
 		return floatValue, nil
 	}
-	return 0.0, fmt.Errorf("value at %s is not a float", k)
+	return 0.0, fmt.Errorf("%w: value at %s is not a float", ErrTypeMismatch, k)
 }
 
 // FindFloatOr is similar to FindFloat but returns the defaultValue if the value is not found or not a float.
 func (j *JsonMapper) FindFloatOr(k string, defaultValue float64) float64 {
-	floatValue, err := j.FindFloat(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asFloat(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return floatValue
+	return value
 }
 
 // FindSlice searches for a slice at the given keyPath.
@@ -359,19 +607,28 @@ func (j *JsonMapper) FindSlice(k string) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	return j.asSlice(tmp, k)
+}
+
+// asSlice converts the value found at k for FindSlice.
+func (j *JsonMapper) asSlice(tmp interface{}, k string) ([]interface{}, error) {
 	if sliceValue, ok := tmp.([]interface{}); ok {
 		return sliceValue, nil
 	}
-	return nil, fmt.Errorf("value at %s is not a slice", k)
+	return nil, fmt.Errorf("%w: value at %s is not a slice", ErrTypeMismatch, k)
 }
 
 // FindSliceOr is similar to FindSlice but returns the defaultValue if the value is not found or not a slice.
 func (j *JsonMapper) FindSliceOr(k string, defaultValue []interface{}) []interface{} {
-	sliceValue, err := j.FindSlice(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asSlice(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return sliceValue
+	return value
 }
 
 // FindMap searches for a map at the given keyPath.
@@ -381,19 +638,28 @@ func (j *JsonMapper) FindMap(k string) (map[string]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	return j.asMap(tmp, k)
+}
+
+// asMap converts the value found at k for FindMap.
+func (j *JsonMapper) asMap(tmp interface{}, k string) (map[string]interface{}, error) {
 	if mapValue, ok := tmp.(map[string]interface{}); ok {
 		return mapValue, nil
 	}
-	return nil, fmt.Errorf("value at %s is not a map", k)
+	return nil, fmt.Errorf("%w: value at %s is not a map", ErrTypeMismatch, k)
 }
 
 // FindMapOr is similar to FindMap but returns the defaultValue if the value is not found or not a map.
 func (j *JsonMapper) FindMapOr(k string, defaultValue map[string]interface{}) map[string]interface{} {
-	mapValue, err := j.FindMap(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asMap(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return mapValue
+	return value
 }
 
 // FindUint searches for an unsigned integer value at the given keyPath.
@@ -403,19 +669,29 @@ func (j *JsonMapper) FindUint(k string) (uint, error) {
 	if err != nil {
 		return 0, err
 	}
-	if floatValue, ok := tmp.(float64); ok {
-		return uint(floatValue), nil
+	return j.asUint(tmp, k)
+}
+
+// asUint converts the value found at k for FindUint.
+func (j *JsonMapper) asUint(tmp interface{}, k string) (uint, error) {
+	tmp = j.coerceNumber(tmp)
+	if uintValue, ok := toUint64(tmp); ok {
+		return uint(uintValue), nil
 	}
-	return 0, fmt.Errorf("value at %s is not an uint", k)
+	return 0, fmt.Errorf("%w: value at %s is not an uint", ErrTypeMismatch, k)
 }
 
 // FindUintOr is similar to FindUint but returns the defaultValue if the value is not found or not an unsigned integer.
 func (j *JsonMapper) FindUintOr(k string, defaultValue uint) uint {
-	uintValue, err := j.FindUint(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asUint(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return uintValue
+	return value
 }
 
 // FindUint32 searches for an unsigned 32-bit integer value at the given keyPath.
@@ -425,19 +701,29 @@ func (j *JsonMapper) FindUint32(k string) (uint32, error) {
 	if err != nil {
 		return 0, err
 	}
-	if floatValue, ok := tmp.(float64); ok {
-		return uint32(floatValue), nil
+	return j.asUint32(tmp, k)
+}
+
+// asUint32 converts the value found at k for FindUint32.
+func (j *JsonMapper) asUint32(tmp interface{}, k string) (uint32, error) {
+	tmp = j.coerceNumber(tmp)
+	if uintValue, ok := toUint64(tmp); ok {
+		return uint32(uintValue), nil
 	}
-	return 0, fmt.Errorf("value at %s is not an uint32", k)
+	return 0, fmt.Errorf("%w: value at %s is not an uint32", ErrTypeMismatch, k)
 }
 
 // FindUint32Or is similar to FindUint32 but returns the defaultValue if the value is not found or not an unsigned 32-bit integer.
 func (j *JsonMapper) FindUint32Or(k string, defaultValue uint32) uint32 {
-	uint32Value, err := j.FindUint32(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asUint32(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return uint32Value
+	return value
 }
 
 // FindUint64 searches for an unsigned 64-bit integer value at the given keyPath.
@@ -447,19 +733,29 @@ func (j *JsonMapper) FindUint64(k string) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if floatValue, ok := tmp.(float64); ok {
-		return uint64(floatValue), nil
+	return j.asUint64(tmp, k)
+}
+
+// asUint64 converts the value found at k for FindUint64.
+func (j *JsonMapper) asUint64(tmp interface{}, k string) (uint64, error) {
+	tmp = j.coerceNumber(tmp)
+	if uintValue, ok := toUint64(tmp); ok {
+		return uintValue, nil
 	}
-	return 0, fmt.Errorf("value at %s is not an uint64", k)
+	return 0, fmt.Errorf("%w: value at %s is not an uint64", ErrTypeMismatch, k)
 }
 
 // FindUint64Or is similar to FindUint64 but returns the defaultValue if the value is not found or not an unsigned 64-bit integer.
 func (j *JsonMapper) FindUint64Or(k string, defaultValue uint64) uint64 {
-	uint64Value, err := j.FindUint64(k)
+	tmp, ok := j.lookup(k)
+	if !ok {
+		return defaultValue
+	}
+	value, err := j.asUint64(tmp, k)
 	if err != nil {
 		return defaultValue
 	}
-	return uint64Value
+	return value
 }
 
 // FindSliceOfMaps searches for a slice of maps at the given keyPath.
@@ -475,12 +771,12 @@ func (j *JsonMapper) FindSliceOfMaps(k string) ([]map[string]interface{}, error)
 			if m, ok := item.(map[string]interface{}); ok {
 				sliceOfMaps = append(sliceOfMaps, m)
 			} else {
-				return nil, fmt.Errorf("element in slice at %s is not a map", k)
+				return nil, fmt.Errorf("%w: element in slice at %s is not a map", ErrTypeMismatch, k)
 			}
 		}
 		return sliceOfMaps, nil
 	}
-	return nil, fmt.Errorf("value at %s is not a slice of maps", k)
+	return nil, fmt.Errorf("%w: value at %s is not a slice of maps", ErrTypeMismatch, k)
 }
 
 // FindMapOfSlices searches for a map of slices at the given keyPath.
@@ -496,27 +792,21 @@ func (j *JsonMapper) FindMapOfSlices(k string) (map[string][]interface{}, error)
 			if slice, ok := value.([]interface{}); ok {
 				mapOfSlices[key] = slice
 			} else {
-				return nil, fmt.Errorf("value for key %s in map at %s is not a slice", key, k)
+				return nil, fmt.Errorf("%w: value for key %s in map at %s is not a slice", ErrTypeMismatch, key, k)
 			}
 		}
 		return mapOfSlices, nil
 	}
-	return nil, fmt.Errorf("value at %s is not a map of slices", k)
+	return nil, fmt.Errorf("%w: value at %s is not a map of slices", ErrTypeMismatch, k)
 }
 
 // WriteFile saves the current JSON structure to a file at the specified filePath.
-// The 'pretty' parameter controls whether the JSON is formatted with indentation.
+// The 'pretty' parameter controls whether the JSON is formatted with indentation (see PrintOptions.Indent).
 // Overwrites the file if it already exists, or creates a new file if it does not.
+// The output honors the options set with SetPrintOptions.
 // Returns an error if writing to the file fails.
 func (j *JsonMapper) WriteFile(filePath string, pretty bool) error {
-	var data []byte
-	var err error
-
-	if pretty {
-		data, err = json.MarshalIndent(j.m, "", "  ")
-	} else {
-		data, err = json.Marshal(j.m)
-	}
+	data, err := j.marshalJSON(j.printOptions, pretty)
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %v", err)
 	}
@@ -529,15 +819,113 @@ func (j *JsonMapper) WriteFile(filePath string, pretty bool) error {
 	return nil
 }
 
+// toFloat64 returns the value of a JSON number decoded either as float64 or, with UseNumber, as json.Number.
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toInt64 is like toFloat64 but converts the number to an int64, truncating any fraction.
+// The exact digits of a json.Number are used when they fit, so large integers keep their precision.
+func toInt64(v interface{}) (int64, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, true
+		}
+	}
+	f, ok := toFloat64(v)
+	return int64(f), ok
+}
+
+// exactInt64 converts a number to an int64 without truncating. The returned error describes
+// why the value is not an exact int64 and is meant to follow a description of the value, e.g. "value at x".
+func exactInt64(v interface{}) (int64, error) {
+	var f float64
+	switch n := v.(type) {
+	case json.Number:
+		i, err := strconv.ParseInt(string(n), 10, 64)
+		if err == nil {
+			return i, nil
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("overflows int64")
+		}
+		if f, err = n.Float64(); err != nil {
+			return 0, fmt.Errorf("is not an int64")
+		}
+	case float64:
+		f = n
+	default:
+		return 0, fmt.Errorf("is not an int64")
+	}
+
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("has a fractional part")
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("overflows int64")
+	}
+	return int64(f), nil
+}
+
+// toUint64 is like toInt64 but converts the number to a uint64.
+func toUint64(v interface{}) (uint64, bool) {
+	if n, ok := v.(json.Number); ok {
+		if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+			return u, true
+		}
+	}
+	f, ok := toFloat64(v)
+	return uint64(f), ok
+}
+
 // convertBracketsToDots transforms array index accessors from bracket notation [index] to dot notation .index in a keyPath.
 // Facilitates uniform handling of array indexes in keyPaths, aligning with the dot-separated keyPath format used by other functions.
 // This internal function supports the parsing and manipulation of keyPaths with array indexes.
 func convertBracketsToDots(keyPath string) string {
-	re := regexp.MustCompile(`\[\-?(\d+)\]`)
-	return re.ReplaceAllStringFunc(keyPath, func(match string) string {
-		index := strings.Trim(match, "[]")
-		return "." + index
-	})
+	if strings.IndexByte(keyPath, '[') < 0 {
+		return keyPath
+	}
+
+	var b strings.Builder
+	b.Grow(len(keyPath))
+	for i := 0; i < len(keyPath); {
+		if end := bracketIndexEnd(keyPath, i); end > 0 {
+			b.WriteByte('.')
+			b.WriteString(keyPath[i+1 : end-1])
+			i = end
+			continue
+		}
+		b.WriteByte(keyPath[i])
+		i++
+	}
+	return b.String()
+}
+
+// bracketIndexEnd reports whether an index accessor of the form [digits] or [-digits] starts at keyPath[i],
+// returning the position just after its closing bracket, or 0 if there is none.
+func bracketIndexEnd(keyPath string, i int) int {
+	if keyPath[i] != '[' {
+		return 0
+	}
+	j := i + 1
+	if j < len(keyPath) && keyPath[j] == '-' {
+		j++
+	}
+	digits := j
+	for j < len(keyPath) && keyPath[j] >= '0' && keyPath[j] <= '9' {
+		j++
+	}
+	if j == digits || j >= len(keyPath) || keyPath[j] != ']' {
+		return 0
+	}
+	return j + 1
 }
 
 // TODO: go version 1.18 + update gopls
@@ -0,0 +1,156 @@
+package jsonmapper_v2
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestFindInt64(t *testing.T) {
+	const doc = `{"id": 1234567890123456789, "big": 92233720368547758070, "frac": 1.5, "exp": 1e3, "neg": -42, "s": "1"}`
+
+	j, _ := NewJsonMapStr(doc, UseNumber())
+	if id, err := j.FindInt64("id"); err != nil || id != 1234567890123456789 {
+		t.Errorf("id = %d, %v", id, err)
+	}
+	if exp, err := j.FindInt64("exp"); err != nil || exp != 1000 {
+		t.Errorf("exp = %d, %v", exp, err)
+	}
+	for _, k := range []string{"big", "frac", "s", "missing"} {
+		if _, err := j.FindInt64(k); err == nil {
+			t.Errorf("%s: expected error", k)
+		}
+	}
+	if got := j.FindInt64Or("frac", 7); got != 7 {
+		t.Errorf("FindInt64Or = %d", got)
+	}
+
+	plain, _ := NewJsonMapStr(doc)
+	if neg, err := plain.FindInt64("neg"); err != nil || neg != -42 {
+		t.Errorf("neg = %d, %v", neg, err)
+	}
+	for _, k := range []string{"big", "frac"} {
+		if _, err := plain.FindInt64(k); err == nil {
+			t.Errorf("float64 %s: expected error", k)
+		}
+	}
+}
+
+func TestFindBigNumbers(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"n": 123456789012345678901234567890, "s": "-98765432109876543210", "amount": "0.1", "f": 2.5, "exp": 1e30, "x": "abc"}`, UseNumber())
+
+	for k, want := range map[string]string{
+		"n":   "123456789012345678901234567890",
+		"s":   "-98765432109876543210",
+		"exp": "1000000000000000000000000000000",
+	} {
+		if got, err := j.FindBigInt(k); err != nil || got.String() != want {
+			t.Errorf("FindBigInt(%s) = %v, %v; want %s", k, got, err, want)
+		}
+	}
+	for _, k := range []string{"amount", "f", "x", "missing"} {
+		if _, err := j.FindBigInt(k); err == nil {
+			t.Errorf("FindBigInt(%s): expected error", k)
+		}
+	}
+
+	amount, err := j.FindBigFloat("amount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := new(big.Float).SetPrec(amount.Prec()).Add(amount, amount)
+	sum.Add(sum, amount)
+	if got := sum.Text('f', 20); got != "0.30000000000000000000" {
+		t.Errorf("0.1 * 3 = %s", got)
+	}
+	if f, err := j.FindBigFloat("f"); err != nil || f.String() != "2.5" {
+		t.Errorf("FindBigFloat(f) = %v, %v", f, err)
+	}
+	if _, err := j.FindBigFloat("x"); err == nil {
+		t.Error("expected error for a non-numeric string")
+	}
+}
+
+func TestConvertBracketsToDots(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"", ""},
+		{"a.b", "a.b"},
+		{"a[0]", "a.0"},
+		{"a[12].b[-1]", "a.12.b.-1"},
+		{"a[0][1]", "a.0.1"},
+		{"a[x]", "a[x]"},
+		{"a[]", "a[]"},
+		{"a[-]", "a[-]"},
+		{"a[1", "a[1"},
+		{"a[[2]]", "a[.2]"},
+	} {
+		if got := convertBracketsToDots(tt.in); got != tt.want {
+			t.Errorf("convertBracketsToDots(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSegmentScanner(t *testing.T) {
+	for _, keyPath := range []string{
+		"a", "a.b.c", "a[0]", "a[0].b", "a[0][-1]", "[0].a", "a.[0]", "a.", ".a", "a..b",
+		"a[x].b", "a[0]b", "a[0]b[1]", "a[1][2]c.d", "a[", "a[-]",
+	} {
+		want := splitKeyPath(keyPath)
+		if !contiguousSegments(keyPath) {
+			continue
+		}
+		var got []string
+		scanner := segmentScanner{path: keyPath}
+		for {
+			segment, ok := scanner.next()
+			if !ok {
+				break
+			}
+			got = append(got, segment)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("segments of %q = %q, want %q", keyPath, got, want)
+		}
+	}
+
+	for keyPath, want := range map[string]bool{"a[0]b": false, "a[0]b[1]": false, "a[0][1]": true, "a[x]b": true} {
+		if got := contiguousSegments(keyPath); got != want {
+			t.Errorf("contiguousSegments(%q) = %v, want %v", keyPath, got, want)
+		}
+	}
+}
+
+func TestFindNoAllocs(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	for _, keyPath := range []string{"testData.nested.string", "testData.s2[1].name", "testData.sliced.2"} {
+		if _, err := j.Find(keyPath); err != nil {
+			t.Fatal(err)
+		}
+		if allocs := testing.AllocsPerRun(100, func() { _, _ = j.Find(keyPath) }); allocs != 0 {
+			t.Errorf("Find(%q) allocates %v times", keyPath, allocs)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = j.FindStringOr("testData.nested.string", "") }); allocs != 0 {
+		t.Errorf("FindStringOr allocates %v times", allocs)
+	}
+
+	for _, keyPath := range []string{"testData.nested.missing", "testData.sliced[9]", "missing.path"} {
+		if allocs := testing.AllocsPerRun(100, func() { _ = j.FindStringOr(keyPath, "") }); allocs != 0 {
+			t.Errorf("FindStringOr(%q) on a missing path allocates %v times", keyPath, allocs)
+		}
+	}
+	defaults, _ := NewJsonMapStr(`{"fallback": {"key": "value"}}`)
+	_ = j.WithFallback(defaults)
+	if got := j.FindStringOr("fallback.key", ""); got != "value" {
+		t.Errorf("FindStringOr() through fallback = %q", got)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _ = j.FindIntOr("fallback.missing", 0) }); allocs != 0 {
+		t.Errorf("FindIntOr() missing in both documents allocates %v times", allocs)
+	}
+
+	j.m["a"] = map[string]interface{}{"0b": "joined"}
+	if got, err := j.FindString("a[0]b"); err != nil || got != "joined" {
+		t.Errorf("FindString(a[0]b) = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,147 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// KeyStyle is a naming convention for object keys, used by TransformKeys.
+type KeyStyle int
+
+const (
+	// KeySnakeCase writes keys like "user_id".
+	KeySnakeCase KeyStyle = iota
+	// KeyCamelCase writes keys like "userId".
+	KeyCamelCase
+	// KeyKebabCase writes keys like "user-id".
+	KeyKebabCase
+	// KeyPascalCase writes keys like "UserId".
+	KeyPascalCase
+)
+
+// TransformKeys renames every object key in the value at keyPath, and in everything nested below it,
+// to the given style, for bridging APIs with different naming conventions. Words are detected at
+// underscores, hyphens, spaces and case changes, so "userID", "user_id", "user-id" and "UserId"
+// all become "user_id" in KeySnakeCase. An empty keyPath transforms the whole document;
+// otherwise the key holding the value itself is left unchanged.
+// Returns an error, leaving the document unchanged, if the path does not exist, the style is unknown,
+// or two keys of the same object would end up with the same name.
+func (j *JsonMapper) TransformKeys(keyPath string, style KeyStyle) error {
+	if style < KeySnakeCase || style > KeyPascalCase {
+		return fmt.Errorf("unsupported key style: %d", style)
+	}
+
+	j.materialize()
+	return j.mutate("transformKeys", keyPath, style, func() error {
+		m, _ := deepCopy(j.m).(map[string]interface{})
+		keys := splitKeyPath(keyPath)
+		value, ok := lookupIn(m, keys)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, keyPath)
+		}
+		transformed, err := transformKeys(value, style)
+		if err != nil {
+			return err
+		}
+
+		if len(keys) == 0 {
+			m, _ = transformed.(map[string]interface{})
+		} else if _, err := editIn(m, keys, false, func(interface{}, bool) (interface{}, error) {
+			return transformed, nil
+		}); err != nil {
+			return err
+		}
+		j.m = m
+		return nil
+	})
+}
+
+// transformKeys returns v with the keys of all nested objects converted to style.
+func transformKeys(v interface{}, style KeyStyle) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for _, k := range sortedKeys(value) {
+			child, err := transformKeys(value[k], style)
+			if err != nil {
+				return nil, err
+			}
+			name := convertKey(k, style)
+			if _, exists := out[name]; exists {
+				return nil, fmt.Errorf("key %s collides with another key as %s", k, name)
+			}
+			out[name] = child
+		}
+		return out, nil
+	case []interface{}:
+		for i, child := range value {
+			transformed, err := transformKeys(child, style)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = transformed
+		}
+		return value, nil
+	default:
+		return v, nil
+	}
+}
+
+// convertKey converts a single key to style.
+func convertKey(key string, style KeyStyle) string {
+	words := splitWords(key)
+	if len(words) == 0 {
+		return key
+	}
+
+	switch style {
+	case KeySnakeCase:
+		return strings.Join(words, "_")
+	case KeyKebabCase:
+		return strings.Join(words, "-")
+	default:
+		var b strings.Builder
+		for i, word := range words {
+			if i == 0 && style == KeyCamelCase {
+				b.WriteString(word)
+				continue
+			}
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+		return b.String()
+	}
+}
+
+// splitWords splits key into lowercase words at separators and case changes.
+// A run of upper case letters is kept together as an acronym, so "HTTPServer" yields "http" and "server".
+func splitWords(key string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(key)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(word) > 0:
+			prev := word[len(word)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextIsLower {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
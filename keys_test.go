@@ -0,0 +1,56 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestConvertKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		style KeyStyle
+		want  string
+	}{
+		{"userId", KeySnakeCase, "user_id"},
+		{"HTTPServer", KeySnakeCase, "http_server"},
+		{"userID", KeyKebabCase, "user-id"},
+		{"user_id", KeyCamelCase, "userId"},
+		{"user-id", KeyPascalCase, "UserId"},
+		{"UserId", KeyCamelCase, "userId"},
+		{"address2_line", KeyCamelCase, "address2Line"},
+		{"__", KeyCamelCase, "__"},
+	}
+	for _, tt := range tests {
+		if got := convertKey(tt.key, tt.style); got != tt.want {
+			t.Errorf("convertKey(%q, %d) = %q, want %q", tt.key, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestTransformKeys(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"userName": "a", "user_profile": {"firstName": "b", "phoneNumbers": [{"countryCode": 1}]}}`)
+
+	if err := j.TransformKeys("user_profile", KeySnakeCase); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"userName":"a","user_profile":{"first_name":"b","phone_numbers":[{"country_code":1}]}}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if err := j.TransformKeys("", KeyCamelCase); err != nil {
+		t.Fatal(err)
+	}
+	want = `{"userName":"a","userProfile":{"firstName":"b","phoneNumbers":[{"countryCode":1}]}}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	collide, _ := NewJsonMapStr(`{"user_id": 1, "userId": 2}`)
+	if err := collide.TransformKeys("", KeySnakeCase); err == nil {
+		t.Error("expected collision error")
+	}
+	if got := collide.Print(); got != `{"userId":2,"user_id":1}` {
+		t.Errorf("document changed on error: %s", got)
+	}
+	if err := j.TransformKeys("missing", KeySnakeCase); err == nil {
+		t.Error("expected error for a missing path")
+	}
+}
@@ -0,0 +1,76 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Kind identifies the JSON type of a value in the document.
+type Kind int
+
+const (
+	// KindInvalid is returned for values that have no JSON representation.
+	KindInvalid Kind = iota
+	KindObject
+	KindArray
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+)
+
+// String returns the lowercase JSON name of the kind, e.g. "object" or "number".
+func (k Kind) String() string {
+	switch k {
+	case KindObject:
+		return "object"
+	case KindArray:
+		return "array"
+	case KindString:
+		return "string"
+	case KindNumber:
+		return "number"
+	case KindBool:
+		return "bool"
+	case KindNull:
+		return "null"
+	default:
+		return "invalid"
+	}
+}
+
+// TypeOf returns the JSON kind of the value at keyPath, so callers can branch on the type
+// of a value without trying several typed Find calls. Go numeric types added through Add
+// are reported as KindNumber. An empty keyPath refers to the root object.
+// Like Find, it consults the fallback mapper set with WithFallback.
+// Returns an error if the path does not exist.
+func (j *JsonMapper) TypeOf(keyPath string) (Kind, error) {
+	value, ok := j.lookupWithFallback(keyPath)
+	if !ok {
+		return KindInvalid, fmt.Errorf("%w: %s", ErrKeyNotFound, keyPath)
+	}
+	return kindOf(value), nil
+}
+
+// kindOf maps a Go value to its JSON kind.
+func kindOf(value interface{}) Kind {
+	switch value.(type) {
+	case map[string]interface{}:
+		return KindObject
+	case []interface{}:
+		return KindArray
+	case string:
+		return KindString
+	case bool:
+		return KindBool
+	case nil:
+		return KindNull
+	case json.Number:
+		return KindNumber
+	default:
+		if isNumeric(value) {
+			return KindNumber
+		}
+		return KindInvalid
+	}
+}
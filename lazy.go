@@ -0,0 +1,187 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// NewJsonMapLazy initializes a new JsonMapper instance in lazy mode from a slice of bytes containing a JSON object.
+// Only the top level of the document is decoded up front; every value below it is kept as a json.RawMessage
+// and decoded when a path first passes through it, one level at a time, so loading a very large document
+// does not materialize the entire tree. Values returned by Find and passed to callbacks are always fully decoded.
+//
+// Operations that visit the whole document, such as FindAllWithCondition, Walk, Flatten, Stats, DiffPaths,
+// Equal, Merge and the non-JSON writers, decode every remaining subtree first.
+// Print, PrettyPrint, WriteFile and WriteJSON write undecoded subtrees as they are.
+// Options such as UseNumber control how values are decoded.
+// Because reads store the subtrees they decode, a lazy mapper is not safe for concurrent use,
+// not even for reads only; synchronize access to it or call Find from a single goroutine.
+// Returns an error if the data is not a valid JSON object.
+func NewJsonMapLazy(data []byte, opts ...Option) (*JsonMapper, error) {
+	return decodeLazy(data, resolveOptions(opts))
+}
+
+// Lazy makes NewJsonMapStr, NewJsonMapBytes, NewJsonMapFile and NewJsonMapReader create the mapper
+// in lazy mode, decoding subtrees on first access as described for NewJsonMapLazy.
+// NewJsonMapReader reads the whole input before parsing it.
+func Lazy() Option {
+	return func(o *decodeOptions) {
+		o.lazy = true
+	}
+}
+
+// decodeLazy implements NewJsonMapLazy with resolved options.
+func decodeLazy(data []byte, o decodeOptions) (*JsonMapper, error) {
+	data, err := o.prepare(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("JSON document is not an object")
+	}
+
+	m := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		m[k] = wrapRaw(v, o.useNumber)
+	}
+	return &JsonMapper{m: m, lazy: true}, nil
+}
+
+// numberRawMessage is an undecoded value of a lazy document created with UseNumber.
+// Its numbers are decoded as json.Number.
+type numberRawMessage json.RawMessage
+
+// MarshalJSON returns m as the JSON encoding of itself.
+func (m numberRawMessage) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(m).MarshalJSON()
+}
+
+// wrapRaw returns data as an undecoded value, remembering whether its numbers are decoded as json.Number.
+func wrapRaw(data json.RawMessage, useNumber bool) interface{} {
+	if useNumber {
+		return numberRawMessage(data)
+	}
+	return data
+}
+
+// rawData returns the bytes of an undecoded value and whether its numbers are decoded as json.Number.
+// ok is false if v is not an undecoded value.
+func rawData(v interface{}) (data []byte, useNumber bool, ok bool) {
+	switch raw := v.(type) {
+	case json.RawMessage:
+		return raw, false, true
+	case numberRawMessage:
+		return raw, true, true
+	}
+	return nil, false, false
+}
+
+// isRaw reports whether v is a value of a lazy document that has not been decoded yet.
+func isRaw(v interface{}) bool {
+	_, _, ok := rawData(v)
+	return ok
+}
+
+// unmarshalRaw decodes data into dst, decoding numbers as json.Number if useNumber is set.
+func unmarshalRaw(data []byte, useNumber bool, dst interface{}) error {
+	return newDecoder(bytes.NewReader(data), decodeOptions{useNumber: useNumber}).Decode(dst)
+}
+
+// materialize decodes every subtree of a lazy document that has not been decoded yet.
+// It does nothing for documents that were not created in lazy mode.
+func (j *JsonMapper) materialize() {
+	if j.lazy {
+		materializeValue(j.m)
+	}
+}
+
+// expandLazy decodes one level of an undecoded value: objects become maps and arrays become slices
+// whose children are still undecoded, while scalars are decoded completely.
+// Any other value is returned unchanged.
+func expandLazy(v interface{}) interface{} {
+	data, useNumber, ok := rawData(v)
+	if !ok {
+		return v
+	}
+
+	switch first := firstByte(data); first {
+	case '{':
+		var children map[string]json.RawMessage
+		if err := json.Unmarshal(data, &children); err != nil {
+			return v
+		}
+		m := make(map[string]interface{}, len(children))
+		for k, child := range children {
+			m[k] = wrapRaw(child, useNumber)
+		}
+		return m
+	case '[':
+		var children []json.RawMessage
+		if err := json.Unmarshal(data, &children); err != nil {
+			return v
+		}
+		s := make([]interface{}, len(children))
+		for i, child := range children {
+			s[i] = wrapRaw(child, useNumber)
+		}
+		return s
+	default:
+		var value interface{}
+		if err := unmarshalRaw(data, useNumber, &value); err != nil {
+			return v
+		}
+		return value
+	}
+}
+
+// materializeValue decodes every undecoded value within v. Maps and slices are updated in place,
+// and the decoded value is returned so that callers can store it if v itself was undecoded.
+func materializeValue(v interface{}) interface{} {
+	if data, useNumber, ok := rawData(v); ok {
+		var decoded interface{}
+		if err := unmarshalRaw(data, useNumber, &decoded); err != nil {
+			return v
+		}
+		return decoded
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, child := range value {
+			if isContainerOrRaw(child) {
+				value[k] = materializeValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range value {
+			if isContainerOrRaw(child) {
+				value[i] = materializeValue(child)
+			}
+		}
+	}
+	return v
+}
+
+// isContainerOrRaw reports whether v may hold undecoded values.
+func isContainerOrRaw(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return isRaw(v)
+}
+
+// firstByte returns the first non-whitespace byte of data, or 0 if there is none.
+func firstByte(data []byte) byte {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if len(data) == 0 {
+		return 0
+	}
+	return data[0]
+}
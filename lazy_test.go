@@ -0,0 +1,117 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	j, err := NewJsonMapLazy([]byte(test_nested_json_string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := j.m["testData"].(json.RawMessage); !ok {
+		t.Fatalf("testData decoded up front: %T", j.m["testData"])
+	}
+
+	if name, err := j.FindString("testData.s2[1].name"); err != nil || name != "bob" {
+		t.Errorf("name = %q, %v", name, err)
+	}
+	testData := j.m["testData"].(map[string]interface{})
+	if _, ok := testData["nested"].(json.RawMessage); !ok {
+		t.Errorf("untouched sibling decoded: %T", testData["nested"])
+	}
+
+	nested, _ := j.Find("testData.nested")
+	if _, ok := nested.(map[string]interface{})["number"].(float64); !ok {
+		t.Errorf("Find returned undecoded values: %v", nested)
+	}
+
+	if err := j.AppendAll("testData.sliced", 6.0); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Set("testData.bool", false); err != nil {
+		t.Fatal(err)
+	}
+	if kind, err := j.TypeOf("testData.string"); err != nil || kind != KindString {
+		t.Errorf("TypeOf = %v, %v", kind, err)
+	}
+	if !j.Exists("testData.s2[2].id") {
+		t.Error("Exists failed on lazy document")
+	}
+
+	expected, _ := NewJsonMapStr(test_nested_json_string)
+	expected.AppendAll("testData.sliced", 6.0)
+	expected.Set("testData.bool", false)
+	if j.Print() != expected.Print() {
+		t.Errorf("got %s, want %s", j.Print(), expected.Print())
+	}
+
+	paths, _ := j.FindAllWithCondition("", map[string]interface{}{"eq": "world"})
+	if len(paths) != 1 || paths[0] != "testData.nested.string" {
+		t.Errorf("paths = %v", paths)
+	}
+	if !j.Equal(expected) {
+		t.Error("materialized document differs")
+	}
+
+	if _, err := NewJsonMapLazy([]byte(`[1]`)); err == nil {
+		t.Error("expected error for a non-object document")
+	}
+	if _, err := NewJsonMapLazy([]byte(`{"a": [1,}`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestLazyFindRoot(t *testing.T) {
+	j, err := NewJsonMapLazy([]byte(`{"a": {"b": [1, {"c": "x"}]}, "n": 9007199254740993}`), UseNumber())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := j.Find("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := root.(map[string]interface{})
+	if n, ok := m["n"].(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Errorf("n = %#v, want json.Number", m["n"])
+	}
+	a, _ := m["a"].(map[string]interface{})
+	b, _ := a["b"].([]interface{})
+	if len(b) != 2 {
+		t.Fatalf("a = %#v, want decoded object", m["a"])
+	}
+	if c, _ := b[1].(map[string]interface{}); c["c"] != "x" {
+		t.Errorf("a.b[1] = %#v, want decoded object", b[1])
+	}
+}
+
+func TestLazyOption(t *testing.T) {
+	data := `{"a": {"b": [1, 2, {"c": "x"}]}, "d": {"e": 9007199254740993}}`
+
+	for name, newMapper := range map[string]func() (*JsonMapper, error){
+		"Str":    func() (*JsonMapper, error) { return NewJsonMapStr(data, Lazy(), UseNumber()) },
+		"Bytes":  func() (*JsonMapper, error) { return NewJsonMapBytes([]byte(data), Lazy(), UseNumber()) },
+		"Reader": func() (*JsonMapper, error) { return NewJsonMapReader(strings.NewReader(data), Lazy(), UseNumber()) },
+	} {
+		j, err := newMapper()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !j.lazy || !isRaw(j.m["d"]) {
+			t.Errorf("%s: mapper is not lazy", name)
+		}
+		if got := j.FindStringOr("a.b[2].c", ""); got != "x" {
+			t.Errorf("%s: FindStringOr() = %q", name, got)
+		}
+		if got, err := j.FindInt64("d.e"); err != nil || got != 9007199254740993 {
+			t.Errorf("%s: FindInt64() = %d, %v", name, got, err)
+		}
+	}
+
+	if _, err := NewJsonMapStr(`[1]`, Lazy()); err == nil {
+		t.Error("expected error for non-object document")
+	}
+}
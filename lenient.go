@@ -0,0 +1,98 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Lenient accepts human-edited JSON such as configuration files: // line comments and /* block comments */,
+// trailing commas before a closing } or ], and unquoted object keys made of letters, digits, _ and $
+// (e.g. {port: 8080,}). The input is rewritten to strict JSON before it is decoded.
+func Lenient() Option {
+	return func(o *decodeOptions) {
+		o.lenient = true
+	}
+}
+
+// toStrictJSON rewrites lenient JSON into strict JSON by removing comments and trailing commas
+// and quoting unquoted object keys. The contents of strings are never changed.
+func toStrictJSON(data []byte) ([]byte, error) {
+	stripped, err := stripComments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(stripped)+16)
+	for i := 0; i < len(stripped); i++ {
+		c := stripped[i]
+		switch {
+		case c == '"':
+			end := stringEnd(stripped, i)
+			out = append(out, stripped[i:end]...)
+			i = end - 1
+		case c == ',':
+			// Drop commas directly followed by the end of an object or array.
+			if next := skipSpace(stripped, i+1); next < len(stripped) && (stripped[next] == '}' || stripped[next] == ']') {
+				continue
+			}
+			out = append(out, c)
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(stripped) && (isIdentStart(stripped[end]) || (stripped[end] >= '0' && stripped[end] <= '9')) {
+				end++
+			}
+			if next := skipSpace(stripped, end); next < len(stripped) && stripped[next] == ':' {
+				out = append(out, '"')
+				out = append(out, stripped[i:end]...)
+				out = append(out, '"')
+			} else {
+				out = append(out, stripped[i:end]...)
+			}
+			i = end - 1
+		default:
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// stripComments removes // and /* */ comments outside of strings.
+// Block comments are replaced by a space so that they still separate tokens.
+func stripComments(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte("/")) {
+		return data, nil
+	}
+
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '"':
+			end := stringEnd(data, i)
+			out = append(out, data[i:end]...)
+			i = end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated block comment")
+			}
+			out = append(out, ' ')
+			i += 2 + end + 1
+		default:
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// isIdentStart reports whether c may start an unquoted object key.
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
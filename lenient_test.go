@@ -0,0 +1,45 @@
+package jsonmapper_v2
+
+import (
+	"strings"
+	"testing"
+)
+
+const lenientConfig = `{
+	// server settings
+	server: {
+		host: "example.com", /* the public name */
+		port: 8080,
+		path: "http://x//y/*z*/", // comment markers inside strings are kept
+	},
+	"tags": ["a", "b",],
+	$id: true,
+}`
+
+func TestLenient(t *testing.T) {
+	if _, err := NewJsonMapStr(lenientConfig); err == nil {
+		t.Fatal("strict parsing should reject lenient input")
+	}
+
+	j, err := NewJsonMapStr(lenientConfig, Lenient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"$id":true,"server":{"host":"example.com","path":"http://x//y/*z*/","port":8080},"tags":["a","b"]}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	r, err := NewJsonMapReader(strings.NewReader(lenientConfig), Lenient())
+	if err != nil || r.Print() != want {
+		t.Errorf("NewJsonMapReader = %v, %v", r, err)
+	}
+	l, err := NewJsonMapLazy([]byte(lenientConfig), Lenient())
+	if err != nil || l.MustFindInt("server.port") != 8080 {
+		t.Errorf("NewJsonMapLazy: %v", err)
+	}
+
+	if _, err := NewJsonMapStr(`{"a": 1 /* open`, Lenient()); err == nil {
+		t.Error("expected error for an unterminated comment")
+	}
+}
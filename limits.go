@@ -0,0 +1,36 @@
+package jsonmapper_v2
+
+import "fmt"
+
+// MaxBytes rejects input larger than n bytes, so a hostile payload cannot exhaust memory.
+// Readers stop reading after n+1 bytes. A value of zero or less disables the limit.
+func MaxBytes(n int) Option {
+	return func(o *decodeOptions) {
+		o.maxBytes = n
+	}
+}
+
+// MaxDepth rejects input whose objects and arrays are nested more than n levels deep; the root object
+// is at depth 1. This keeps recursive operations such as FindAllWithCondition and Walk from exhausting the stack.
+// A value of zero or less disables the limit.
+func MaxDepth(n int) Option {
+	return func(o *decodeOptions) {
+		o.maxDepth = n
+	}
+}
+
+// MaxArrayLength rejects input containing an array with more than n elements.
+// A value of zero or less disables the limit.
+func MaxArrayLength(n int) Option {
+	return func(o *decodeOptions) {
+		o.maxArrayLength = n
+	}
+}
+
+// checkSize returns an error if data exceeds the MaxBytes limit of o.
+func (o decodeOptions) checkSize(data []byte) error {
+	if o.maxBytes > 0 && len(data) > o.maxBytes {
+		return fmt.Errorf("document exceeds maximum size of %d bytes", o.maxBytes)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package jsonmapper_v2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	const doc = `{"a": {"b": [1, 2, 3]}}`
+
+	tests := []struct {
+		opt     Option
+		wantErr string
+	}{
+		{MaxBytes(len(doc)), ""},
+		{MaxBytes(len(doc) - 1), "document exceeds maximum size"},
+		{MaxDepth(3), ""},
+		{MaxDepth(2), "maximum depth of 2 exceeded at a.b"},
+		{MaxArrayLength(3), ""},
+		{MaxArrayLength(2), "array at a.b exceeds maximum length of 2"},
+	}
+	for _, tt := range tests {
+		for name, parse := range map[string]func() (*JsonMapper, error){
+			"str":    func() (*JsonMapper, error) { return NewJsonMapStr(doc, tt.opt) },
+			"reader": func() (*JsonMapper, error) { return NewJsonMapReader(strings.NewReader(doc), tt.opt) },
+			"lazy":   func() (*JsonMapper, error) { return NewJsonMapLazy([]byte(doc), tt.opt) },
+		} {
+			_, err := parse()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("%s: err = %v, want %q", name, err, tt.wantErr)
+			}
+		}
+	}
+
+	deep := strings.Repeat(`{"a":`, 100000) + "1" + strings.Repeat("}", 100000)
+	if _, err := NewJsonMapStr(deep, MaxDepth(64)); err == nil || !strings.Contains(err.Error(), "maximum depth of 64") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+)
+
+// MergeStrategy controls how arrays are combined when two documents are merged.
+// Objects are always merged recursively, and scalar values from the merged document win.
+type MergeStrategy int
+
+const (
+	// MergeReplace replaces an array with the array from the merged document.
+	MergeReplace MergeStrategy = iota
+	// MergeConcat appends the elements of the merged array to the existing array.
+	MergeConcat
+	// MergeByIndex merges array elements pairwise by position, appending any extra elements.
+	MergeByIndex
+)
+
+// Merge deep-merges the document of other into this JsonMapper.
+// Keys present only in other are added, nested objects are merged recursively,
+// and arrays are combined according to strategy. Values taken from other are deep-copied,
+// so the two mappers never share maps or slices afterwards.
+// This is useful for layering configuration files, e.g. defaults merged with user overrides.
+// Returns an error if other is nil or the strategy is unknown.
+func (j *JsonMapper) Merge(other *JsonMapper, strategy MergeStrategy) error {
+	if other == nil {
+		return fmt.Errorf("cannot merge a nil JsonMapper")
+	}
+	if strategy < MergeReplace || strategy > MergeByIndex {
+		return fmt.Errorf("unsupported merge strategy: %d", strategy)
+	}
+
+	j.materialize()
+	other.materialize()
+	return j.mutate("merge", "", nil, func() error {
+		m, _ := deepCopy(j.m).(map[string]interface{})
+		if m == nil {
+			m = make(map[string]interface{})
+		}
+		mergeValues(m, other.m, strategy)
+		j.m = m
+		return nil
+	})
+}
+
+// mergeValues merges src into dst and returns the merged value.
+// Maps in dst are updated in place, while slices are rebuilt as needed.
+func mergeValues(dst, src interface{}, strategy MergeStrategy) interface{} {
+	switch srcValue := src.(type) {
+	case map[string]interface{}:
+		dstMap, ok := dst.(map[string]interface{})
+		if !ok {
+			return deepCopy(srcValue)
+		}
+		for k, v := range srcValue {
+			if existing, ok := dstMap[k]; ok {
+				dstMap[k] = mergeValues(existing, v, strategy)
+			} else {
+				dstMap[k] = deepCopy(v)
+			}
+		}
+		return dstMap
+	case []interface{}:
+		dstSlice, ok := dst.([]interface{})
+		if !ok {
+			return deepCopy(srcValue)
+		}
+		switch strategy {
+		case MergeConcat:
+			merged := make([]interface{}, 0, len(dstSlice)+len(srcValue))
+			merged = append(merged, dstSlice...)
+			for _, v := range srcValue {
+				merged = append(merged, deepCopy(v))
+			}
+			return merged
+		case MergeByIndex:
+			for i, v := range srcValue {
+				if i < len(dstSlice) {
+					dstSlice[i] = mergeValues(dstSlice[i], v, strategy)
+				} else {
+					dstSlice = append(dstSlice, deepCopy(v))
+				}
+			}
+			return dstSlice
+		default:
+			return deepCopy(srcValue)
+		}
+	default:
+		return srcValue
+	}
+}
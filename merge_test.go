@@ -0,0 +1,41 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	override := `{"testData": {"number": 30, "nested": {"extra": 1}, "sliced": [9], "added": "yes"}}`
+
+	tests := []struct {
+		strategy MergeStrategy
+		sliced   string
+	}{
+		{MergeReplace, "[9]"},
+		{MergeConcat, "[1,2,3,4,5,9]"},
+		{MergeByIndex, "[9,2,3,4,5]"},
+	}
+
+	for _, tc := range tests {
+		j, _ := NewJsonMapStr(test_nested_json_string)
+		o, _ := NewJsonMapStr(override)
+		if err := j.Merge(o, tc.strategy); err != nil {
+			t.Fatal(err)
+		}
+
+		s, _ := j.FindSlice("testData.sliced")
+		got, _ := NewJsonMapObject(map[string]interface{}{"v": s})
+		if want := `{"v":` + tc.sliced + `}`; got.Print() != want {
+			t.Errorf("strategy %d: got %s, want %s", tc.strategy, got.Print(), want)
+		}
+		if v := j.FindIntOr("testData.number", 0); v != 30 {
+			t.Errorf("strategy %d: scalar not overridden: %d", tc.strategy, v)
+		}
+		if v := j.FindIntOr("testData.nested.number", 0); v != 15 {
+			t.Errorf("strategy %d: nested key lost: %d", tc.strategy, v)
+		}
+		if v := j.FindStringOr("testData.added", ""); v != "yes" {
+			t.Errorf("strategy %d: new key missing", tc.strategy)
+		}
+	}
+}
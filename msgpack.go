@@ -0,0 +1,38 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// NewJsonMapMsgpack initializes a new JsonMapper instance from MessagePack data,
+// so binary RPC payloads can be manipulated without an intermediate JSON string.
+// Maps become objects with keys formatted as strings, integers and floats become float64,
+// binary data becomes a string and timestamps become RFC 3339 strings.
+// Returns an error if decoding fails or the top-level value is not a map.
+func NewJsonMapMsgpack(data []byte) (*JsonMapper, error) {
+	var v interface{}
+	if err := msgpack.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	m, err := normalizeDocument(v, "MessagePack")
+	if err != nil {
+		return nil, err
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// MarshalMsgpack returns the current structure encoded as MessagePack.
+// Numbers without a fractional part are encoded as integers, and map keys are written in sorted order.
+func (j *JsonMapper) MarshalMsgpack() ([]byte, error) {
+	j.materialize()
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.UseCompactFloats(true)
+	encoder.SetSortMapKeys(true)
+	if err := encoder.Encode(nativeNumbers(j.m)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,37 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	data, err := j.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := NewJsonMapMsgpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Print() != j.Print() {
+		t.Errorf("round trip: got %s, want %s", decoded.Print(), j.Print())
+	}
+	if n, err := decoded.FindInt("testData.nested.number"); err != nil || n != 15 {
+		t.Errorf("number = %v, %v", n, err)
+	}
+
+	var raw map[string]interface{}
+	msgpack.Unmarshal(data, &raw)
+	if _, ok := raw["testData"].(map[string]interface{})["number"].(float64); ok {
+		t.Error("whole numbers should be encoded as integers")
+	}
+
+	scalar, _ := msgpack.Marshal([]int{1, 2})
+	if _, err := NewJsonMapMsgpack(scalar); err == nil {
+		t.Error("expected error for a non-map document")
+	}
+}
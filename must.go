@@ -0,0 +1,115 @@
+package jsonmapper_v2
+
+import "time"
+
+// The Must* methods are like their Find* counterparts but panic with the error instead of returning it.
+// They are intended for tests and program initialization, where a missing or mistyped value
+// indicates a programming mistake rather than a condition to handle.
+
+// MustFind is like Find but panics if the path does not exist.
+func (j *JsonMapper) MustFind(k string) interface{} {
+	value, err := j.Find(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindBool is like FindBool but panics on error.
+func (j *JsonMapper) MustFindBool(k string) bool {
+	value, err := j.FindBool(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindString is like FindString but panics on error.
+func (j *JsonMapper) MustFindString(k string) string {
+	value, err := j.FindString(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindInt is like FindInt but panics on error.
+func (j *JsonMapper) MustFindInt(k string) int {
+	value, err := j.FindInt(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindInt64 is like FindInt64 but panics on error.
+func (j *JsonMapper) MustFindInt64(k string) int64 {
+	value, err := j.FindInt64(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindUint64 is like FindUint64 but panics on error.
+func (j *JsonMapper) MustFindUint64(k string) uint64 {
+	value, err := j.FindUint64(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindFloat is like FindFloat but panics on error.
+func (j *JsonMapper) MustFindFloat(k string) float64 {
+	value, err := j.FindFloat(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindSlice is like FindSlice but panics on error.
+func (j *JsonMapper) MustFindSlice(k string) []interface{} {
+	value, err := j.FindSlice(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindMap is like FindMap but panics on error.
+func (j *JsonMapper) MustFindMap(k string) map[string]interface{} {
+	value, err := j.FindMap(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindStringSlice is like FindStringSlice but panics on error.
+func (j *JsonMapper) MustFindStringSlice(k string) []string {
+	value, err := j.FindStringSlice(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindTime is like FindTime but panics on error.
+func (j *JsonMapper) MustFindTime(k string, layouts ...string) time.Time {
+	value, err := j.FindTime(k, layouts...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustFindDuration is like FindDuration but panics on error.
+func (j *JsonMapper) MustFindDuration(k string) time.Duration {
+	value, err := j.FindDuration(k)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,25 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestMustFind(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	if got := j.MustFindString("testData.nested.string"); got != "world" {
+		t.Errorf("MustFindString = %q", got)
+	}
+	if got := j.MustFindInt("testData.number"); got != 25 {
+		t.Errorf("MustFindInt = %d", got)
+	}
+	if got := j.MustFindSlice("testData.sliced"); len(got) != 5 {
+		t.Errorf("MustFindSlice = %v", got)
+	}
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(error); !ok {
+			t.Errorf("expected panic with an error, got %v", r)
+		}
+	}()
+	j.MustFindInt("testData.string")
+}
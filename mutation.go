@@ -0,0 +1,142 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+)
+
+// Rename changes the name of the object key at keyPath to newKey, keeping its value.
+// The renamed key stays in the same parent object. Returns an error if the path does not exist,
+// its parent is not an object, or newKey is already present in the parent.
+func (j *JsonMapper) Rename(keyPath, newKey string) error {
+	return j.mutate("rename", keyPath, newKey, func() error {
+		keys := splitKeyPath(keyPath)
+		if len(keys) == 0 {
+			return fmt.Errorf("%w: empty", ErrInvalidPath)
+		}
+		if newKey == "" {
+			return fmt.Errorf("new key must not be empty")
+		}
+
+		parent, ok := lookupIn(j.m, keys[:len(keys)-1])
+		object, isObject := parent.(map[string]interface{})
+		if !ok || !isObject {
+			return fmt.Errorf("%w: parent of %s is not an object", ErrTypeMismatch, keyPath)
+		}
+
+		oldKey := keys[len(keys)-1]
+		value, ok := object[oldKey]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, oldKey)
+		}
+		if newKey == oldKey {
+			return nil
+		}
+		if _, exists := object[newKey]; exists {
+			return fmt.Errorf("key already exists: %s", newKey)
+		}
+
+		object[newKey] = value
+		delete(object, oldKey)
+		return nil
+	})
+}
+
+// Move relocates the value at srcPath to dstPath, creating intermediate objects at the destination
+// as needed, like Add. Array elements can be moved as well; the element is removed from its source array
+// before the destination path is resolved, so indexes in dstPath refer to the array after removal.
+// The operation is atomic: if the value cannot be stored at dstPath, it is restored at srcPath.
+// Returns an error if either path is invalid or dstPath lies within srcPath.
+func (j *JsonMapper) Move(srcPath, dstPath string) error {
+	return j.mutate("move", srcPath, dstPath, func() error {
+		srcKeys := splitKeyPath(srcPath)
+		dstKeys := splitKeyPath(dstPath)
+		if len(srcKeys) == 0 || len(dstKeys) == 0 {
+			return fmt.Errorf("%w: empty", ErrInvalidPath)
+		}
+		if hasPrefix(dstKeys, srcKeys) {
+			return fmt.Errorf("cannot move %s into itself", srcPath)
+		}
+
+		parentKeys := srcKeys[:len(srcKeys)-1]
+		parent, ok := lookupIn(j.m, parentKeys)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, srcPath)
+		}
+
+		var value interface{}
+		err := j.edit(srcPath, false, func(old interface{}, exists bool) (interface{}, error) {
+			if !exists {
+				return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, srcPath)
+			}
+			value = old
+			return removeValue, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := j.add(dstPath, value); err != nil {
+			restore(j.m, parentKeys, parent, srcKeys[len(srcKeys)-1], value)
+			return err
+		}
+		return nil
+	})
+}
+
+// Copy stores a deep copy of the value at srcPath at dstPath, creating intermediate objects
+// at the destination as needed, like Add. The source and destination never share maps or slices afterwards.
+// Returns an error if srcPath does not exist or the value cannot be stored at dstPath.
+func (j *JsonMapper) Copy(srcPath, dstPath string) error {
+	return j.mutate("copy", srcPath, dstPath, func() error {
+		value, ok := lookupIn(j.m, splitKeyPath(srcPath))
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, srcPath)
+		}
+		return j.add(dstPath, deepCopy(value))
+	})
+}
+
+// Update replaces the value at keyPath with the result of fn applied to the current value,
+// e.g. to increment a counter or append to a string. fn receives nil if the path does not exist,
+// in which case the result is stored like Add would, creating intermediate objects as needed.
+// If fn returns an error, the document is left unchanged and the error is returned.
+func (j *JsonMapper) Update(keyPath string, fn func(old interface{}) (interface{}, error)) error {
+	old, _ := lookupIn(j.m, splitKeyPath(keyPath))
+	if j.lazy {
+		old = materializeValue(old)
+	}
+	value, err := fn(old)
+	if err != nil {
+		return err
+	}
+	return j.mutate("update", keyPath, value, func() error {
+		return j.add(keyPath, value)
+	})
+}
+
+// restore puts a removed value back into its original parent.
+// Removing an array element replaces the slice in its own parent with a new one, leaving the original
+// slice untouched, so the original slice is simply written back; object keys are re-inserted.
+func restore(root map[string]interface{}, parentKeys []string, parent interface{}, key string, value interface{}) {
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[key] = value
+	case []interface{}:
+		_, _ = editIn(root, parentKeys, false, func(old interface{}, exists bool) (interface{}, error) {
+			return p, nil
+		})
+	}
+}
+
+// hasPrefix reports whether keys starts with all segments of prefix.
+func hasPrefix(keys, prefix []string) bool {
+	if len(prefix) > len(keys) {
+		return false
+	}
+	for i := range prefix {
+		if keys[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
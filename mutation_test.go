@@ -0,0 +1,97 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	if err := j.Rename("testData.s2[0].name", "firstName"); err != nil {
+		t.Fatal(err)
+	}
+	if v := j.FindStringOr("testData.s2.0.firstName", ""); v != "alice" {
+		t.Errorf("renamed value: got %q", v)
+	}
+	if j.Exists("testData.s2[0].name") {
+		t.Error("old key still present")
+	}
+	if err := j.Rename("testData.number", "string"); err == nil {
+		t.Error("expected error renaming onto an existing key")
+	}
+	if err := j.Rename("testData.sliced[0]", "x"); err == nil {
+		t.Error("expected error renaming an array element")
+	}
+}
+
+func TestMove(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	if err := j.Move("testData.s2[0]", "archive.first"); err != nil {
+		t.Fatal(err)
+	}
+	if v := j.FindStringOr("archive.first.name", ""); v != "alice" {
+		t.Errorf("moved value: got %q", v)
+	}
+	if n, _ := j.Len("testData.s2"); n != 2 {
+		t.Errorf("source array length: got %d", n)
+	}
+
+	before := j.Print()
+	if err := j.Move("testData.s2[0]", "testData.string.x"); err == nil {
+		t.Fatal("expected error moving below a scalar")
+	}
+	if j.Print() != before {
+		t.Error("failed move modified the document")
+	}
+	if err := j.Move("testData", "testData.nested.copy"); err == nil {
+		t.Error("expected error moving a value into itself")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	if err := j.Copy("testData.nested", "testData.s2[-1]"); err != nil {
+		t.Fatal(err)
+	}
+	_ = j.Set("testData.s2[3].string", "changed")
+	if v := j.FindStringOr("testData.nested.string", ""); v != "world" {
+		t.Errorf("copy shares data with source: got %q", v)
+	}
+	if err := j.Copy("testData.missing", "x"); err == nil {
+		t.Error("expected error copying a missing path")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	increment := func(old interface{}) (interface{}, error) {
+		n, _ := old.(float64)
+		return n + 1, nil
+	}
+	if err := j.Update("testData.number", increment); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Update("counters.visits", increment); err != nil {
+		t.Fatal(err)
+	}
+	if v := j.FindIntOr("testData.number", 0); v != 26 {
+		t.Errorf("existing value: got %d", v)
+	}
+	if v := j.FindIntOr("counters.visits", 0); v != 1 {
+		t.Errorf("missing value: got %d", v)
+	}
+
+	failing := func(old interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("failed")
+	}
+	if err := j.Update("testData.number", failing); err == nil {
+		t.Error("expected error from transform")
+	}
+	if v := j.FindIntOr("testData.number", 0); v != 26 {
+		t.Errorf("failed update modified the value: got %d", v)
+	}
+}
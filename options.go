@@ -0,0 +1,117 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Option configures how a constructor such as NewJsonMapStr or NewJsonMapFile decodes a JSON document.
+type Option func(*decodeOptions)
+
+type decodeOptions struct {
+	useNumber   bool
+	lenient     bool
+	lazy        bool
+	onDuplicate func(keyPath string) error
+
+	maxBytes       int
+	maxDepth       int
+	maxArrayLength int
+}
+
+// UseNumber decodes numbers as json.Number instead of float64, so that 64-bit identifiers such as
+// 9007199254740993 keep their exact digits. The typed finders, conditions and comparisons accept
+// json.Number transparently.
+func UseNumber() Option {
+	return func(o *decodeOptions) {
+		o.useNumber = true
+	}
+}
+
+// resolveOptions applies opts to the default decoding options.
+func resolveOptions(opts []Option) decodeOptions {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// buffered reports whether o has to see the whole input before decoding it, see prepare.
+func (o decodeOptions) buffered() bool {
+	return o.lenient || o.lazy || o.scanned() || o.maxBytes > 0
+}
+
+// scanned reports whether o requires the structure of the input to be checked, see scanStructure.
+func (o decodeOptions) scanned() bool {
+	return o.onDuplicate != nil || o.maxDepth > 0 || o.maxArrayLength > 0
+}
+
+// prepare enforces the size limit, rewrites lenient input to strict JSON and checks for duplicate keys
+// and the structural limits, as requested by o.
+func (o decodeOptions) prepare(data []byte) ([]byte, error) {
+	if err := o.checkSize(data); err != nil {
+		return nil, err
+	}
+	if o.lenient {
+		strict, err := toStrictJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		data = strict
+	}
+	if o.scanned() {
+		if err := scanStructure(data, o); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// newJsonMap creates a JsonMapper from data according to o, in lazy mode if o.lazy is set.
+func newJsonMap(data []byte, o decodeOptions) (*JsonMapper, error) {
+	if o.lazy {
+		return decodeLazy(data, o)
+	}
+	m, err := decodeObject(data, o)
+	if err != nil {
+		return nil, err
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// decodeObject parses data as a JSON object according to o.
+func decodeObject(data []byte, o decodeOptions) (map[string]interface{}, error) {
+	data, err := o.prepare(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if !o.useNumber {
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	decoder := newDecoder(bytes.NewReader(data), o)
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid data after top-level value")
+	}
+	return m, nil
+}
+
+// newDecoder returns a json.Decoder reading from r configured according to o.
+func newDecoder(r io.Reader, o decodeOptions) *json.Decoder {
+	decoder := json.NewDecoder(r)
+	if o.useNumber {
+		decoder.UseNumber()
+	}
+	return decoder
+}
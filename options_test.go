@@ -0,0 +1,61 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUseNumber(t *testing.T) {
+	const doc = `{"id": 9007199254740993, "price": 1.25, "items": [{"n": 2}, {"n": 3}]}`
+
+	plain, _ := NewJsonMapStr(doc)
+	if id, _ := plain.FindUint64("id"); id == 9007199254740993 {
+		t.Fatal("float64 decoding unexpectedly preserved the id")
+	}
+
+	j, err := NewJsonMapStr(doc, UseNumber())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := j.Find("id"); v != json.Number("9007199254740993") {
+		t.Errorf("id decoded as %#v", v)
+	}
+	if id, err := j.FindUint64("id"); err != nil || id != 9007199254740993 {
+		t.Errorf("FindUint64 = %d, %v", id, err)
+	}
+	if id, err := j.FindInt("id"); err != nil || id != 9007199254740993 {
+		t.Errorf("FindInt = %d, %v", id, err)
+	}
+	if price, err := j.FindFloat("price"); err != nil || price != 1.25 {
+		t.Errorf("FindFloat = %v, %v", price, err)
+	}
+	if kind, _ := j.TypeOf("price"); kind != KindNumber {
+		t.Errorf("TypeOf = %v", kind)
+	}
+	if paths, _ := j.FindAllWithCondition("items", map[string]interface{}{"gt": 2}); len(paths) != 1 || paths[0] != "items[1].n" {
+		t.Errorf("conditions = %v", paths)
+	}
+	if want := `{"id":9007199254740993,"items":[{"n":2},{"n":3}],"price":1.25}`; j.Print() != want {
+		t.Errorf("Print = %s, want %s", j.Print(), want)
+	}
+
+	for name, load := range map[string]func() (*JsonMapper, error){
+		"bytes":  func() (*JsonMapper, error) { return NewJsonMapBytes([]byte(doc), UseNumber()) },
+		"reader": func() (*JsonMapper, error) { return NewJsonMapReader(strings.NewReader(doc), UseNumber()) },
+		"stream": func() (*JsonMapper, error) { return NewJsonMapStream(strings.NewReader(doc), UseNumber()).Next() },
+		"lazy":   func() (*JsonMapper, error) { return NewJsonMapLazy([]byte(doc), UseNumber()) },
+	} {
+		loaded, err := load()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if v, _ := loaded.Find("items[1].n"); v != json.Number("3") {
+			t.Errorf("%s: items[1].n decoded as %#v", name, v)
+		}
+	}
+
+	if _, err := NewJsonMapStr(`{"a": 1} {"b": 2}`, UseNumber()); err == nil {
+		t.Error("expected error for trailing data")
+	}
+}
@@ -0,0 +1,258 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitKeyPath converts a keyPath into its individual segments.
+// Bracket indexes are normalized to dot notation first, so "a.b[0]" yields ["a", "b", "0"].
+// An empty keyPath yields no segments and refers to the root of the document.
+func splitKeyPath(keyPath string) []string {
+	if keyPath == "" {
+		return nil
+	}
+	return strings.Split(convertBracketsToDots(keyPath), ".")
+}
+
+// segmentScanner iterates over the segments splitKeyPath would return for a non-empty path, as substrings of the
+// path, without allocating. It may only be used on paths for which contiguousSegments reports true.
+type segmentScanner struct {
+	path string
+	// pos is the start of the next segment, or -1 once every segment has been returned.
+	pos int
+	// bracketEnd is the position after the closing bracket if the next segment is a bracket index, or 0.
+	bracketEnd int
+}
+
+// next returns the next segment, or false once every segment has been returned.
+func (s *segmentScanner) next() (string, bool) {
+	if s.pos < 0 {
+		return "", false
+	}
+	if s.bracketEnd > 0 {
+		segment := s.path[s.pos : s.bracketEnd-1]
+		s.afterBracket(s.bracketEnd)
+		return segment, true
+	}
+	for i := s.pos; i < len(s.path); i++ {
+		if s.path[i] == '.' {
+			segment := s.path[s.pos:i]
+			s.pos = i + 1
+			return segment, true
+		}
+		if end := bracketIndexEnd(s.path, i); end > 0 {
+			segment := s.path[s.pos:i]
+			s.pos, s.bracketEnd = i+1, end
+			return segment, true
+		}
+	}
+	segment := s.path[s.pos:]
+	s.pos = -1
+	return segment, true
+}
+
+// afterBracket positions the scanner after a bracket index that ends just before end.
+func (s *segmentScanner) afterBracket(end int) {
+	s.bracketEnd = 0
+	switch {
+	case end == len(s.path):
+		s.pos = -1
+	case s.path[end] == '.':
+		s.pos = end + 1
+	default:
+		// contiguousSegments guarantees another bracket index starts here.
+		s.pos, s.bracketEnd = end+1, bracketIndexEnd(s.path, end)
+	}
+}
+
+// contiguousSegments reports whether every bracket index in keyPath is followed by the end of the path,
+// a dot or another bracket index, so that each segment is a substring of keyPath.
+// It is false for unusual paths such as "a[0]b", whose segment "0b" has to be assembled.
+func contiguousSegments(keyPath string) bool {
+	for i := strings.IndexByte(keyPath, '['); i >= 0 && i < len(keyPath); i++ {
+		end := bracketIndexEnd(keyPath, i)
+		if end == 0 {
+			continue
+		}
+		if end < len(keyPath) && keyPath[end] != '.' && bracketIndexEnd(keyPath, end) == 0 {
+			return false
+		}
+		i = end - 1
+	}
+	return true
+}
+
+// editFunc receives the current value at the end of a path and whether it exists,
+// and returns the value that should be stored there instead.
+// Returning removeValue deletes the key or array element.
+type editFunc func(old interface{}, exists bool) (interface{}, error)
+
+// removeValue is a marker returned by an editFunc to delete the edited key or element.
+var removeValue = &struct{}{}
+
+// editIn walks keys starting at node and replaces the value at the last segment with the result of fn.
+// Maps are modified in place, while slices are rebuilt when their length changes and written back to
+// their parent, so paths through arrays are handled the same way as paths through objects.
+//
+// When create is true, missing object keys along the path are created as maps and
+// an index of -1 in the last segment appends to the slice. Otherwise -1 refers to the last element.
+//
+// Returns the (possibly new) node that should replace the given one in its parent.
+func editIn(node interface{}, keys []string, create bool, fn editFunc) (interface{}, error) {
+	key := keys[0]
+	last := len(keys) == 1
+	node = expandLazy(node)
+
+	switch current := node.(type) {
+	case map[string]interface{}:
+		child, ok := current[key]
+		if last {
+			value, err := fn(expandLazy(child), ok)
+			if err != nil {
+				return nil, err
+			}
+			if value == removeValue {
+				delete(current, key)
+			} else {
+				current[key] = value
+			}
+			return current, nil
+		}
+		if !ok {
+			if !create {
+				return nil, newPathError("", key, 0, node, fmt.Errorf("%w: %s", ErrKeyNotFound, key))
+			}
+			child = make(map[string]interface{})
+		}
+		value, err := editIn(child, keys[1:], create, fn)
+		if err != nil {
+			return nil, nestedPathError(err)
+		}
+		current[key] = value
+		return current, nil
+
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, newPathError("", key, 0, node, fmt.Errorf("%w: invalid array index %s", ErrInvalidPath, key))
+		}
+		if last && create && index == -1 {
+			value, err := fn(nil, false)
+			if err != nil {
+				return nil, err
+			}
+			if value == removeValue {
+				return current, nil
+			}
+			return append(current, value), nil
+		}
+		if index == -1 {
+			index = len(current) - 1
+		}
+		if index < 0 || index >= len(current) {
+			return nil, newPathError("", key, 0, node, fmt.Errorf("%w: %d", ErrIndexOutOfRange, index))
+		}
+		if last {
+			value, err := fn(expandLazy(current[index]), true)
+			if err != nil {
+				return nil, err
+			}
+			if value == removeValue {
+				updated := make([]interface{}, 0, len(current)-1)
+				updated = append(updated, current[:index]...)
+				return append(updated, current[index+1:]...), nil
+			}
+			current[index] = value
+			return current, nil
+		}
+		value, err := editIn(current[index], keys[1:], create, fn)
+		if err != nil {
+			return nil, nestedPathError(err)
+		}
+		current[index] = value
+		return current, nil
+
+	default:
+		return nil, newPathError("", key, 0, node, fmt.Errorf("%w: cannot traverse %T at key %s", ErrTypeMismatch, node, key))
+	}
+}
+
+// edit applies fn to the value at keyPath within the document.
+// The root of the document itself cannot be edited.
+func (j *JsonMapper) edit(keyPath string, create bool, fn editFunc) error {
+	keys := splitKeyPath(keyPath)
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: empty", ErrInvalidPath)
+	}
+	if j.m == nil {
+		j.m = make(map[string]interface{})
+	}
+	if j.lazy {
+		// Callbacks always see fully decoded values.
+		edit := fn
+		fn = func(old interface{}, exists bool) (interface{}, error) {
+			return edit(materializeValue(old), exists)
+		}
+	}
+	_, err := editIn(j.m, keys, create, fn)
+	return j.pathError(keyPath, keys, err)
+}
+
+// joinKey appends an object key to a keyPath using dot notation.
+func joinKey(keyPath, key string) string {
+	if keyPath == "" {
+		return key
+	}
+	return keyPath + "." + key
+}
+
+// joinIndex appends an array index to a keyPath using bracket notation.
+func joinIndex(keyPath string, index int) string {
+	return keyPath + "[" + strconv.Itoa(index) + "]"
+}
+
+// lookupIn walks keys starting at node and returns the value found at the end of the path.
+// Unlike Find, traversal fails when a segment cannot be applied to the current value,
+// so a path continuing below a scalar is reported as missing.
+// Undecoded values of lazy documents along the path are decoded one level and stored back.
+func lookupIn(node interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		switch current := node.(type) {
+		case map[string]interface{}:
+			value, ok := current[key]
+			if !ok {
+				return nil, false
+			}
+			node = value
+			if isRaw(value) {
+				node = expandLazy(value)
+				current[key] = node
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			node = current[index]
+			if isRaw(node) {
+				node = expandLazy(node)
+				current[index] = node
+			}
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// parentKeyPath returns the keyPath of the container holding the value at keyPath,
+// by stripping the last ".key" or "[index]" segment.
+func parentKeyPath(keyPath string) string {
+	i := strings.LastIndexAny(keyPath, ".[")
+	if i < 0 {
+		return ""
+	}
+	return keyPath[:i]
+}
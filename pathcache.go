@@ -0,0 +1,110 @@
+package jsonmapper_v2
+
+import (
+	"container/list"
+	"sync"
+)
+
+// EnablePathCache makes Find remember the values of the last size distinct key paths it resolved,
+// for services that read the same handful of paths millions of times. Repeated lookups of a cached
+// path skip parsing and traversal entirely. The cache is cleared by every mutation made through
+// the JsonMapper (Add, Set, Remove, Merge, Undo, ...); changes made directly to maps or slices
+// returned by Find are not detected, so do not combine the cache with such changes.
+// Calling EnablePathCache again replaces the cache with an empty one of the new size.
+//
+// Concurrent Find calls on a mapper with the cache enabled are safe as long as nothing mutates it,
+// except for lazy documents (see NewJsonMapLazy), whose lookups decode subtrees and store them in the
+// document: those must not be read from several goroutines at once, with or without the cache.
+// A size of zero or less disables the cache.
+func (j *JsonMapper) EnablePathCache(size int) {
+	if size <= 0 {
+		j.cache = nil
+		return
+	}
+	j.cache = newPathCache(size)
+}
+
+// DisablePathCache removes the path cache enabled with EnablePathCache.
+func (j *JsonMapper) DisablePathCache() {
+	j.cache = nil
+}
+
+// invalidatePathCache drops every cached lookup after the document has changed.
+func (j *JsonMapper) invalidatePathCache() {
+	if j.cache != nil {
+		j.cache.clear()
+	}
+}
+
+// pathCache is a fixed-size LRU cache of resolved key paths.
+// It is guarded by a mutex because lookups reorder it, so enabling the cache does not stop a mapper that is
+// safe for concurrent reads from being so. It does not make other mappers safe, see EnablePathCache.
+type pathCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List // of *pathCacheEntry, most recently used first
+	items map[string]*list.Element
+}
+
+// pathCacheEntry is the value resolved for a key path.
+type pathCacheEntry struct {
+	keyPath string
+	value   interface{}
+}
+
+// newPathCache returns an empty cache holding up to size paths.
+func newPathCache(size int) *pathCache {
+	return &pathCache{size: size, order: list.New(), items: make(map[string]*list.Element, size)}
+}
+
+// get returns the cached value of keyPath and marks it as recently used.
+func (c *pathCache) get(keyPath string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[keyPath]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*pathCacheEntry).value, true
+}
+
+// put stores the value of keyPath, evicting the least recently used path if the cache is full.
+func (c *pathCache) put(keyPath string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[keyPath]; ok {
+		elem.Value.(*pathCacheEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*pathCacheEntry).keyPath)
+	}
+	c.items[keyPath] = c.order.PushFront(&pathCacheEntry{keyPath: keyPath, value: value})
+}
+
+// empty returns a new empty cache of the same size, or nil if c is nil.
+func (c *pathCache) empty() *pathCache {
+	if c == nil {
+		return nil
+	}
+	return newPathCache(c.size)
+}
+
+// clear removes every cached path.
+func (c *pathCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.items = make(map[string]*list.Element, c.size)
+}
+
+// len returns the number of cached paths.
+func (c *pathCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,89 @@
+package jsonmapper_v2
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPathCache(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	j.EnablePathCache(2)
+
+	if got := j.FindStringOr("testData.nested.string", ""); got != "world" {
+		t.Fatalf("FindStringOr() = %q", got)
+	}
+	_ = j.FindIntOr("testData.number", 0)
+	_ = j.FindBoolOr("testData.bool", false)
+	if n := j.cache.len(); n != 2 {
+		t.Errorf("cache holds %d paths, want 2", n)
+	}
+	if _, ok := j.cache.get("testData.nested.string"); ok {
+		t.Error("least recently used path was not evicted")
+	}
+	if _, err := j.Find("testData.missing"); err == nil || j.cache.len() != 2 {
+		t.Errorf("failed lookup was cached: %v", err)
+	}
+
+	_ = j.FindStringOr("testData.nested.string", "")
+	if err := j.Set("testData.nested.string", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if n := j.cache.len(); n != 0 {
+		t.Errorf("cache holds %d paths after Set, want 0", n)
+	}
+	if got := j.FindStringOr("testData.nested.string", ""); got != "changed" {
+		t.Errorf("FindStringOr() after Set = %q", got)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = j.FindStringOr("testData.nested.string", "") }); allocs != 0 {
+		t.Errorf("cached lookup allocates %v times", allocs)
+	}
+
+	clone := j.Clone()
+	if clone.cache == nil || clone.cache == j.cache || clone.cache.len() != 0 {
+		t.Error("Clone did not create an empty cache of its own")
+	}
+
+	j.DisablePathCache()
+	if j.cache != nil {
+		t.Error("DisablePathCache did not remove the cache")
+	}
+}
+
+func TestPathCacheUndo(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": 1}`)
+	j.EnableHistory(10)
+	j.EnablePathCache(10)
+
+	_ = j.Set("a", 2.0)
+	if got := j.FindIntOr("a", 0); got != 2 {
+		t.Fatalf("FindIntOr() = %d", got)
+	}
+	_ = j.Undo()
+	if got := j.FindIntOr("a", 0); got != 1 {
+		t.Errorf("FindIntOr() after Undo = %d, want 1", got)
+	}
+	_ = j.Redo()
+	if got := j.FindIntOr("a", 0); got != 2 {
+		t.Errorf("FindIntOr() after Redo = %d, want 2", got)
+	}
+}
+
+func TestPathCacheConcurrentReads(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	j.EnablePathCache(2)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				_ = j.FindStringOr("testData.nested.string", "")
+				_ = j.FindIntOr("testData.s2[1].id", 0)
+				_ = j.FindBoolOr("testData.bool", false)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,71 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// PrintOptions controls how Print, PrettyPrint, WriteFile and WriteJSON serialize the document.
+// The zero value matches the default behavior.
+type PrintOptions struct {
+	// SortKeys guarantees that the keys of every object are written in sorted order, so output is
+	// deterministic and diff-friendly. Decoded objects are always written sorted; the option additionally
+	// decodes the subtrees of lazy documents (see NewJsonMapLazy) that would otherwise be copied from the input
+	// in their original key order.
+	SortKeys bool
+	// Indent is the indentation per nesting level of pretty output, e.g. "\t" or four spaces.
+	// An empty Indent uses two spaces.
+	Indent string
+	// Prefix begins every line of pretty output after the first, e.g. to nest the output in a log message.
+	Prefix string
+	// DisableHTMLEscape writes <, > and & verbatim instead of as \u003c, \u003e and \u0026,
+	// so URLs and HTML snippets round-trip unchanged.
+	DisableHTMLEscape bool
+}
+
+// SetPrintOptions sets the options used by Print, PrettyPrint, WriteFile and WriteJSON from now on.
+func (j *JsonMapper) SetPrintOptions(opts PrintOptions) {
+	j.printOptions = opts
+}
+
+// PrettyPrintWith is like PrettyPrint but indents each nesting level with indent and begins every line
+// after the first with prefix, instead of the indentation set with SetPrintOptions.
+func (j *JsonMapper) PrettyPrintWith(indent, prefix string) string {
+	opts := j.printOptions
+	opts.Indent = indent
+	opts.Prefix = prefix
+	jsonString, err := j.marshalJSON(opts, true)
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonString)
+}
+
+// encodeJSON writes the document to w according to opts, indented if pretty is true
+// and followed by a newline.
+func (j *JsonMapper) encodeJSON(w io.Writer, opts PrintOptions, pretty bool) error {
+	if opts.SortKeys {
+		j.materialize()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(!opts.DisableHTMLEscape)
+	if pretty {
+		indent := opts.Indent
+		if indent == "" {
+			indent = "  "
+		}
+		encoder.SetIndent(opts.Prefix, indent)
+	}
+	return encoder.Encode(j.m)
+}
+
+// marshalJSON returns the document encoded according to opts, without a trailing newline.
+func (j *JsonMapper) marshalJSON(opts PrintOptions, pretty bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := j.encodeJSON(&buf, opts, pretty); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
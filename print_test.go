@@ -0,0 +1,73 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrintOptionsSortKeys(t *testing.T) {
+	j, err := NewJsonMapLazy([]byte(`{"b": {"z": 1, "a": [ {"y": 2, "x": 1} ]}, "a": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := j.Print(); got != `{"a":true,"b":{"z":1,"a":[{"y":2,"x":1}]}}` {
+		t.Errorf("undecoded subtree should keep its key order, got %s", got)
+	}
+
+	j.SetPrintOptions(PrintOptions{SortKeys: true})
+	want := `{"a":true,"b":{"a":[{"x":1,"y":2}],"z":1}}`
+	if got := j.Print(); got != want {
+		t.Errorf("Print = %s, want %s", got, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.json")
+	if err := j.Clone().WriteFile(path, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("WriteFile = %s, want %s", data, want)
+	}
+}
+
+func TestPrettyPrintWith(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": {"b": 1}}`)
+
+	if got, want := j.PrettyPrintWith("\t", ""), "{\n\t\"a\": {\n\t\t\"b\": 1\n\t}\n}"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := j.PrettyPrintWith("    ", "> "), "{\n>     \"a\": {\n>         \"b\": 1\n>     }\n> }"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := j.PrettyPrint(), "{\n  \"a\": {\n    \"b\": 1\n  }\n}"; got != want {
+		t.Errorf("PrettyPrintWith must not change the defaults, got %q", got)
+	}
+
+	j.SetPrintOptions(PrintOptions{Indent: "\t"})
+	path := filepath.Join(t.TempDir(), "out.json")
+	if err := j.WriteFile(path, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{\n\t\"a\": {\n\t\t\"b\": 1\n\t}\n}" {
+		t.Errorf("WriteFile = %q", data)
+	}
+}
+
+func TestPrintOptionsDisableHTMLEscape(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"url": "https://example.com/?a=1&b=2", "html": "<b>hi</b>"}`)
+
+	if got := j.Print(); got != `{"html":"\u003cb\u003ehi\u003c/b\u003e","url":"https://example.com/?a=1\u0026b=2"}` {
+		t.Errorf("default output should escape HTML, got %s", got)
+	}
+
+	j.SetPrintOptions(PrintOptions{DisableHTMLEscape: true})
+	want := `{"html":"<b>hi</b>","url":"https://example.com/?a=1&b=2"}`
+	if got := j.Print(); got != want {
+		t.Errorf("Print = %s, want %s", got, want)
+	}
+	var buf strings.Builder
+	if err := j.WriteJSON(&buf, false); err != nil || buf.String() != want+"\n" {
+		t.Errorf("WriteJSON = %q, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,359 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query parses expr with ParseQuery and returns the paths of all values satisfying it,
+// as FindAllWithCondition would. The options sort and paginate the results.
+//
+// Example:
+// paths, err := jm.Query(`testData.s2[*].id > 1 && name =~ "^b"`)
+// returns ["testData.s2[1]"] for the document used throughout the examples.
+func (j *JsonMapper) Query(expr string, opts ...QueryOption) ([]string, error) {
+	keyPath, conditions, err := ParseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	return j.FindAllWithCondition(keyPath, conditions, opts...)
+}
+
+// ParseQuery converts a query expression into the keyPath and conditions accepted by FindAllWithCondition,
+// so conditions can be read from configuration files or user input.
+//
+// An expression is made of comparisons combined with "&&", "||", "!" and parentheses.
+// A comparison is written as `path operator literal`, where operator is one of
+// ==, !=, <, <=, >, >=, =~ (regex) or the name of any condition operation such as in, nin, contains,
+// startsWith, endsWith, ieq, icontains, between, type or exists.
+// Literals are double- or single-quoted strings, numbers, true, false, null, or lists such as [1, 2, "x"].
+//
+// A path containing "[*]" selects the elements of an array: the part before "[*]" becomes the returned keyPath
+// and the part after it names a field of each element, e.g. `testData.s2[*].id > 1`. Further comparisons
+// name fields relative to the same elements (`&& name =~ "^a"`). The path "@" refers to the evaluated value
+// itself, so `testData.sliced[*] > 2` and `@ > 2` compare values rather than fields.
+// All "[*]" paths in an expression must refer to the same array.
+func ParseQuery(expr string) (string, map[string]interface{}, error) {
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+	conditions, err := p.parseOr()
+	if err != nil {
+		return "", nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return "", nil, fmt.Errorf("unexpected token %q at offset %d", p.tokens[p.pos].text, p.tokens[p.pos].offset)
+	}
+	return p.scope, conditions, nil
+}
+
+// queryOperators maps the symbolic comparison operators of the query language to condition operations.
+var queryOperators = map[string]string{
+	"==": "eq",
+	"!=": "neq",
+	"<":  "lt",
+	"<=": "lte",
+	">":  "gt",
+	">=": "gte",
+	"=~": "regex",
+}
+
+type queryTokenKind int
+
+const (
+	tokenIdent queryTokenKind = iota
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	text   string
+	value  interface{}
+	offset int
+}
+
+// tokenizeQuery splits a query expression into identifiers (paths and keywords), literals and symbols.
+func tokenizeQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != c {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			raw := expr[i : end+1]
+			if c == '\'' {
+				raw = `"` + strings.ReplaceAll(strings.ReplaceAll(raw[1:len(raw)-1], `\'`, `'`), `"`, `\"`) + `"`
+			}
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %v", i, err)
+			}
+			tokens = append(tokens, queryToken{kind: tokenString, text: expr[i : end+1], value: value, offset: i})
+			i = end + 1
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(expr) && strings.IndexByte("0123456789.eE+-", expr[end]) >= 0 {
+				end++
+			}
+			value, err := strconv.ParseFloat(expr[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at offset %d", expr[i:end], i)
+			}
+			tokens = append(tokens, queryToken{kind: tokenNumber, text: expr[i:end], value: value, offset: i})
+			i = end
+		case c == '_' || c == '@' || c == '$' || unicode.IsLetter(rune(c)):
+			end := i + 1
+			for end < len(expr) {
+				d := expr[end]
+				if d == '_' || d == '.' || d == '-' || unicode.IsLetter(rune(d)) || unicode.IsDigit(rune(d)) {
+					end++
+					continue
+				}
+				if d == '[' {
+					if close := strings.IndexByte(expr[end:], ']'); close > 1 && isIndexSegment(expr[end+1:end+close]) {
+						end += close + 1
+						continue
+					}
+				}
+				break
+			}
+			tokens = append(tokens, queryToken{kind: tokenIdent, text: expr[i:end], offset: i})
+			i = end
+		default:
+			if i+1 < len(expr) {
+				if two := expr[i : i+2]; two == "&&" || two == "||" || queryOperators[two] != "" {
+					tokens = append(tokens, queryToken{kind: tokenSymbol, text: two, offset: i})
+					i += 2
+					continue
+				}
+			}
+			if strings.IndexByte("()[],!<>", c) < 0 {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+			}
+			tokens = append(tokens, queryToken{kind: tokenSymbol, text: string(c), offset: i})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// isIndexSegment reports whether s, the content of a bracket pair, is an array index or the "*" wildcard.
+func isIndexSegment(s string) bool {
+	if s == "*" {
+		return true
+	}
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// queryParser is a recursive descent parser over the tokens of a query expression.
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	scope  string
+	scoped bool
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return queryToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *queryParser) acceptSymbol(symbol string) bool {
+	if t, ok := p.peek(); ok && t.kind == tokenSymbol && t.text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr parses: and ( "||" and )*
+func (p *queryParser) parseOr() (map[string]interface{}, error) {
+	return p.parseBinary("||", "or", p.parseAnd)
+}
+
+// parseAnd parses: unary ( "&&" unary )*
+func (p *queryParser) parseAnd() (map[string]interface{}, error) {
+	return p.parseBinary("&&", "and", p.parseUnary)
+}
+
+func (p *queryParser) parseBinary(symbol, op string, next func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+	operands := []interface{}{first}
+	for p.acceptSymbol(symbol) {
+		operand, err := next()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return map[string]interface{}{op: operands}, nil
+}
+
+// parseUnary parses: "!" unary | "(" or ")" | comparison
+func (p *queryParser) parseUnary() (map[string]interface{}, error) {
+	if p.acceptSymbol("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"not": operand}, nil
+	}
+	if p.acceptSymbol("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptSymbol(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses: path operator literal
+func (p *queryParser) parseComparison() (map[string]interface{}, error) {
+	t, ok := p.peek()
+	if !ok || t.kind != tokenIdent {
+		return nil, p.errorf("expected a path")
+	}
+	p.pos++
+	field, err := p.resolvePath(t.text)
+	if err != nil {
+		return nil, err
+	}
+
+	opToken, ok := p.peek()
+	if !ok {
+		return nil, p.errorf("expected an operator")
+	}
+	var op string
+	switch {
+	case opToken.kind == tokenSymbol && queryOperators[opToken.text] != "":
+		op = queryOperators[opToken.text]
+	case opToken.kind == tokenIdent && comparisonOps[opToken.text]:
+		op = opToken.text
+	default:
+		return nil, p.errorf("unknown operator %q", opToken.text)
+	}
+	p.pos++
+
+	value, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+
+	condition := map[string]interface{}{op: value}
+	if field == "" {
+		return condition, nil
+	}
+	return map[string]interface{}{field: condition}, nil
+}
+
+// resolvePath splits a comparison path into the query scope and a field relative to it.
+// It returns an empty field when the comparison applies to the evaluated value itself.
+func (p *queryParser) resolvePath(path string) (string, error) {
+	if path == "@" {
+		return "", nil
+	}
+	i := strings.Index(path, "[*]")
+	if i < 0 {
+		return path, nil
+	}
+
+	scope := path[:i]
+	if p.scoped && scope != p.scope {
+		return "", fmt.Errorf("conflicting query scopes: %s and %s", p.scope, scope)
+	}
+	p.scope, p.scoped = scope, true
+
+	field := strings.TrimPrefix(path[i+3:], ".")
+	if strings.Contains(field, "[*]") {
+		return "", fmt.Errorf("nested wildcards are not supported: %s", path)
+	}
+	return field, nil
+}
+
+// parseLiteral parses: string | number | true | false | null | "[" literal ( "," literal )* "]"
+func (p *queryParser) parseLiteral() (interface{}, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, p.errorf("expected a value")
+	}
+
+	switch {
+	case t.kind == tokenString || t.kind == tokenNumber:
+		p.pos++
+		return t.value, nil
+	case t.kind == tokenIdent && t.text == "true":
+		p.pos++
+		return true, nil
+	case t.kind == tokenIdent && t.text == "false":
+		p.pos++
+		return false, nil
+	case t.kind == tokenIdent && t.text == "null":
+		p.pos++
+		return nil, nil
+	case t.kind == tokenSymbol && t.text == "[":
+		p.pos++
+		list := []interface{}{}
+		if p.acceptSymbol("]") {
+			return list, nil
+		}
+		for {
+			element, err := p.parseLiteral()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, element)
+			if p.acceptSymbol("]") {
+				return list, nil
+			}
+			if !p.acceptSymbol(",") {
+				return nil, p.errorf("expected ',' or ']'")
+			}
+		}
+	default:
+		return nil, p.errorf("unexpected token %q, expected a value", t.text)
+	}
+}
+
+// errorf returns a parse error annotated with the offset of the current token.
+func (p *queryParser) errorf(format string, args ...interface{}) error {
+	offset := -1
+	if t, ok := p.peek(); ok {
+		offset = t.offset
+	}
+	msg := fmt.Sprintf(format, args...)
+	if offset < 0 {
+		return fmt.Errorf("%s at end of query", msg)
+	}
+	return fmt.Errorf("%s at offset %d", msg, offset)
+}
@@ -0,0 +1,139 @@
+package jsonmapper_v2
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QueryOption adjusts the results returned by FindAllWithCondition, FindAllWithConditionValues and Query.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	limit   int
+	offset  int
+	sortBy  querySort
+	desc    bool
+	limited bool
+	workers int
+}
+
+type querySort int
+
+const (
+	sortNone querySort = iota
+	sortPath
+	sortValue
+)
+
+// Limit returns at most n results. A negative n means no limit.
+// Unless a sort option is given, results are ordered by path so that pages are deterministic.
+func Limit(n int) QueryOption {
+	return func(o *queryOptions) {
+		o.limit = n
+		o.limited = true
+	}
+}
+
+// Offset skips the first n results.
+// Unless a sort option is given, results are ordered by path so that pages are deterministic.
+func Offset(n int) QueryOption {
+	return func(o *queryOptions) {
+		if n > 0 {
+			o.offset = n
+		}
+		o.limited = true
+	}
+}
+
+// SortByPath orders results by path in document order: object keys sort alphabetically
+// and array elements by index, so "a[2]" comes before "a[10]".
+func SortByPath(desc bool) QueryOption {
+	return func(o *queryOptions) {
+		o.sortBy = sortPath
+		o.desc = desc
+	}
+}
+
+// SortByValue orders results by their value, using the same ordering as SortArray.
+// Results with equal values are ordered by path in ascending order.
+func SortByValue(desc bool) QueryOption {
+	return func(o *queryOptions) {
+		o.sortBy = sortValue
+		o.desc = desc
+	}
+}
+
+// Parallel evaluates the children of the starting value concurrently using up to workers goroutines,
+// which can cut latency for documents with many large top-level subtrees on multicore machines.
+// The results are the same as those of a sequential search; values of workers below 2 disable it.
+// The document must not be modified while the search runs.
+func Parallel(workers int) QueryOption {
+	return func(o *queryOptions) {
+		o.workers = workers
+	}
+}
+
+// resolveQueryOptions applies opts to the default options.
+func resolveQueryOptions(opts []QueryOption) queryOptions {
+	o := queryOptions{limit: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// applyQueryOptions sorts and paginates matches according to opts.
+func applyQueryOptions(matches []Match, opts []QueryOption) []Match {
+	if len(opts) == 0 {
+		return matches
+	}
+	o := resolveQueryOptions(opts)
+	if o.sortBy == sortNone && o.limited {
+		o.sortBy = sortPath
+	}
+
+	if o.sortBy != sortNone {
+		sort.SliceStable(matches, func(a, b int) bool {
+			var c int
+			if o.sortBy == sortValue {
+				c = compareValues(matches[a].Value, matches[b].Value)
+			} else {
+				c = comparePaths(matches[a].Path, matches[b].Path)
+			}
+			if o.desc {
+				c = -c
+			}
+			if c == 0 {
+				c = comparePaths(matches[a].Path, matches[b].Path)
+			}
+			return c < 0
+		})
+	}
+
+	if o.offset >= len(matches) {
+		return nil
+	}
+	matches = matches[o.offset:]
+	if o.limit >= 0 && o.limit < len(matches) {
+		matches = matches[:o.limit]
+	}
+	return matches
+}
+
+// comparePaths orders two keyPaths segment by segment, comparing array indexes numerically.
+func comparePaths(a, b string) int {
+	keysA, keysB := splitKeyPath(a), splitKeyPath(b)
+	for i := 0; i < len(keysA) && i < len(keysB); i++ {
+		if keysA[i] == keysB[i] {
+			continue
+		}
+		indexA, errA := strconv.Atoi(keysA[i])
+		indexB, errB := strconv.Atoi(keysB[i])
+		if errA == nil && errB == nil {
+			return indexA - indexB
+		}
+		return strings.Compare(keysA[i], keysB[i])
+	}
+	return len(keysA) - len(keysB)
+}
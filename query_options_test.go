@@ -0,0 +1,65 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryOptions(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": [5, 1, 4, 2, 3, 9, 8, 7, 6, 10, 11]}`)
+	numbers := map[string]interface{}{"type": "number"}
+
+	got, err := j.FindAllWithCondition("a", numbers, SortByPath(false), Offset(8), Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a[8]", "a[9]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("by path: got %v, want %v", got, want)
+	}
+
+	got, _ = j.FindAllWithCondition("a", numbers, Limit(2))
+	if want := []string{"a[0]", "a[1]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("default order: got %v, want %v", got, want)
+	}
+
+	matches, _ := j.FindAllWithConditionValues("a", numbers, SortByValue(true), Limit(3))
+	var values []interface{}
+	for _, m := range matches {
+		values = append(values, m.Value)
+	}
+	if want := []interface{}{11.0, 10.0, 9.0}; !reflect.DeepEqual(values, want) {
+		t.Errorf("by value: got %v, want %v", values, want)
+	}
+
+	if got, _ = j.FindAllWithCondition("a", numbers, Offset(20)); len(got) != 0 {
+		t.Errorf("offset past end: got %v", got)
+	}
+}
+
+func TestComparePaths(t *testing.T) {
+	if comparePaths("a[2]", "a[10]") >= 0 || comparePaths("a.b", "a") <= 0 || comparePaths("a.x", "a.y") >= 0 {
+		t.Error("unexpected path order")
+	}
+}
+
+func TestParallel(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	conditions := map[string]interface{}{"or": []interface{}{
+		map[string]interface{}{"type": "number"},
+		map[string]interface{}{"exists": "name"},
+	}}
+
+	for _, keyPath := range []string{"", "testData", "testData.s2", "testData.number"} {
+		want, err := j.FindAllWithCondition(keyPath, conditions, SortByPath(false))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := j.FindAllWithCondition(keyPath, conditions, Parallel(4), SortByPath(false))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %v, want %v", keyPath, got, want)
+		}
+	}
+}
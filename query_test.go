@@ -0,0 +1,60 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`testData.s2[*].id > 1 && name =~ "^b"`, []string{"testData.s2[1]"}},
+		{`testData.s2[*].id == 1 || (name startsWith 'c' && !(id < 3))`, []string{"testData.s2[0]", "testData.s2[2]"}},
+		{`testData.s2[*].name in ["alice", "cindy"]`, []string{"testData.s2[0]", "testData.s2[2]"}},
+		{`testData.sliced[*] >= 4`, []string{"testData.sliced[3]", "testData.sliced[4]"}},
+		{`testData.nested.number == 15 && testData.bool == true`, []string{""}},
+	}
+	for _, tt := range tests {
+		got, err := j.Query(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	keyPath, conditions, err := ParseQuery(`a.b[*].id > 1 && !(name == "x")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"and": []interface{}{
+		map[string]interface{}{"id": map[string]interface{}{"gt": 1.0}},
+		map[string]interface{}{"not": map[string]interface{}{"name": map[string]interface{}{"eq": "x"}}},
+	}}
+	if keyPath != "a.b" || !reflect.DeepEqual(conditions, want) {
+		t.Errorf("got %q %v", keyPath, conditions)
+	}
+
+	for _, expr := range []string{
+		``,
+		`id >`,
+		`id ~ 1`,
+		`id == "open`,
+		`(id == 1`,
+		`a[*].id == 1 && b[*].id == 2`,
+		`id == 1 name == 2`,
+	} {
+		if _, _, err := ParseQuery(expr); err == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+}
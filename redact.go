@@ -0,0 +1,135 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Redact replaces the values at the given paths with mask so the document can be logged safely.
+// Paths use the usual notation, e.g. "db.password" or "users[0].token", and a "*" segment matches every key
+// of an object or every element of an array, e.g. "users[*].token" or "users.*.token".
+// Whole objects and arrays are replaced as well. Paths that do not exist are skipped.
+// Returns an error if a path is empty.
+func (j *JsonMapper) Redact(paths []string, mask string) error {
+	return j.rewritePaths("redact", paths, func(interface{}) (interface{}, error) {
+		return mask, nil
+	})
+}
+
+// RedactKeys replaces the value of every object key whose name matches pattern with mask,
+// anywhere in the document including inside arrays, e.g. with the pattern `(?i)password|token|secret`.
+// Returns an error if pattern is not a valid regular expression.
+func (j *JsonMapper) RedactKeys(pattern string, mask string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid key pattern: %v", err)
+	}
+
+	j.materialize()
+	return j.mutate("redact", "", nil, func() error {
+		m, _ := deepCopy(j.m).(map[string]interface{})
+		redactKeys(m, re, mask)
+		j.m = m
+		return nil
+	})
+}
+
+// rewritePaths applies fn to the values at paths on a copy of the document and stores the copy on success,
+// recording the change as a single mutation named op.
+func (j *JsonMapper) rewritePaths(op string, paths []string, fn func(interface{}) (interface{}, error)) error {
+	for _, path := range paths {
+		if path == "" {
+			return fmt.Errorf("%w: empty", ErrInvalidPath)
+		}
+	}
+
+	j.materialize()
+	return j.mutate(op, "", nil, func() error {
+		m, _ := deepCopy(j.m).(map[string]interface{})
+		for _, path := range paths {
+			if err := rewritePath(m, splitKeyPath(convertWildcards(path)), fn); err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+		}
+		j.m = m
+		return nil
+	})
+}
+
+// wildcardIndex matches a [*] segment.
+var wildcardIndex = regexp.MustCompile(`\[\*\]`)
+
+// convertWildcards rewrites [*] segments to dot notation, which splitKeyPath leaves untouched.
+func convertWildcards(keyPath string) string {
+	return wildcardIndex.ReplaceAllString(keyPath, ".*")
+}
+
+// rewritePath replaces the values matched by keys below node with the result of fn.
+// A "*" segment matches every key of an object or element of an array; values that do not exist are skipped.
+func rewritePath(node interface{}, keys []string, fn func(interface{}) (interface{}, error)) error {
+	key := keys[0]
+	last := len(keys) == 1
+
+	switch current := node.(type) {
+	case map[string]interface{}:
+		for k, child := range current {
+			if key != "*" && k != key {
+				continue
+			}
+			if !last {
+				if err := rewritePath(child, keys[1:], fn); err != nil {
+					return err
+				}
+				continue
+			}
+			value, err := fn(child)
+			if err != nil {
+				return err
+			}
+			current[k] = value
+		}
+	case []interface{}:
+		for i, child := range current {
+			if key != "*" {
+				index, err := strconv.Atoi(key)
+				if index == -1 {
+					index = len(current) - 1
+				}
+				if err != nil || i != index {
+					continue
+				}
+			}
+			if !last {
+				if err := rewritePath(child, keys[1:], fn); err != nil {
+					return err
+				}
+				continue
+			}
+			value, err := fn(child)
+			if err != nil {
+				return err
+			}
+			current[i] = value
+		}
+	}
+	return nil
+}
+
+// redactKeys replaces the values of all keys matching re within node with mask.
+func redactKeys(node interface{}, re *regexp.Regexp, mask string) {
+	switch current := node.(type) {
+	case map[string]interface{}:
+		for k, child := range current {
+			if re.MatchString(k) {
+				current[k] = mask
+			} else {
+				redactKeys(child, re, mask)
+			}
+		}
+	case []interface{}:
+		for _, child := range current {
+			redactKeys(child, re, mask)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package jsonmapper_v2
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"db": {"password": "p", "host": "h"}, "users": [{"name": "a", "token": "t1"}, {"name": "b", "token": "t2"}], "keys": ["k1", "k2"]}`)
+
+	if err := j.Redact([]string{"db.password", "users[*].token", "keys[-1]", "missing.path"}, "***"); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"db":{"host":"h","password":"***"},"keys":["k1","***"],"users":[{"name":"a","token":"***"},{"name":"b","token":"***"}]}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if err := j.Redact([]string{""}, "***"); err == nil {
+		t.Error("expected error for an empty path")
+	}
+}
+
+func TestRedactKeys(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"Password": "p", "nested": {"apiToken": {"v": 1}, "ok": 1}, "list": [{"secret": "s"}]}`)
+
+	if err := j.RedactKeys(`(?i)password|token|secret`, "[REDACTED]"); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Password":"[REDACTED]","list":[{"secret":"[REDACTED]"}],"nested":{"apiToken":"[REDACTED]","ok":1}}`
+	if got := j.Print(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if err := j.RedactKeys(`(`, "x"); err == nil {
+		t.Error("expected error for an invalid pattern")
+	}
+}
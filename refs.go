@@ -0,0 +1,141 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// refPattern matches a {{path}} placeholder and captures the path.
+var refPattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// ExpandRefs resolves {{path.to.value}} placeholders inside string values using values from the same document,
+// so configuration can refer to other settings instead of repeating them.
+// A string consisting of a single placeholder is replaced by a copy of the referenced value, keeping its type,
+// e.g. "{{defaults.port}}" becomes the number 8080. Placeholders embedded in longer strings are replaced by
+// the referenced string, or by the JSON encoding of any other value.
+// Referenced values are expanded first, so references may be chained.
+// Returns an error, leaving the document unchanged, if a referenced path does not exist
+// or references form a cycle.
+func (j *JsonMapper) ExpandRefs() error {
+	j.materialize()
+	return j.mutate("expandRefs", "", nil, func() error {
+		m, _ := deepCopy(j.m).(map[string]interface{})
+		e := &refExpander{m: m, done: map[string]bool{}, active: map[string]bool{}}
+		if _, err := e.expand(m, ""); err != nil {
+			return err
+		}
+		j.m = m
+		return nil
+	})
+}
+
+// refExpander tracks which paths have been expanded and which are being expanded,
+// the latter in order so that cycles can be reported.
+type refExpander struct {
+	m      map[string]interface{}
+	done   map[string]bool
+	active map[string]bool
+	stack  []string
+}
+
+// expand replaces the placeholders within value, which is located at path, and returns the expanded value.
+// Maps and slices are updated in place.
+func (e *refExpander) expand(value interface{}, path string) (interface{}, error) {
+	key := strings.Join(splitKeyPath(path), ".")
+	if e.done[key] {
+		return value, nil
+	}
+	if e.active[key] {
+		return nil, fmt.Errorf("reference cycle: %s -> %s", strings.Join(e.stack, " -> "), path)
+	}
+	e.active[key] = true
+	if path != "" {
+		e.stack = append(e.stack, path)
+	}
+	defer func() {
+		delete(e.active, key)
+		if path != "" {
+			e.stack = e.stack[:len(e.stack)-1]
+		}
+	}()
+
+	var err error
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			if v[k], err = e.expand(v[k], joinKey(path, k)); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if v[i], err = e.expand(v[i], joinIndex(path, i)); err != nil {
+				return nil, err
+			}
+		}
+	case string:
+		if value, err = e.expandString(v); err != nil {
+			return nil, err
+		}
+	}
+	e.done[key] = true
+	return value, nil
+}
+
+// expandString replaces the placeholders within s.
+func (e *refExpander) expandString(s string) (interface{}, error) {
+	if match := refPattern.FindStringSubmatchIndex(s); match != nil && match[0] == 0 && match[1] == len(s) {
+		value, err := e.resolve(s[match[2]:match[3]])
+		if err != nil {
+			return nil, err
+		}
+		return deepCopy(value), nil
+	}
+
+	var err error
+	expanded := refPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		if err != nil {
+			return ""
+		}
+		var value interface{}
+		if value, err = e.resolve(refPattern.FindStringSubmatch(placeholder)[1]); err != nil {
+			return ""
+		}
+		if str, ok := value.(string); ok {
+			return str
+		}
+		data, marshalErr := json.Marshal(value)
+		if marshalErr != nil {
+			err = marshalErr
+			return ""
+		}
+		return string(data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
+
+// resolve returns the expanded value at path, expanding it and storing the result first if necessary.
+func (e *refExpander) resolve(path string) (interface{}, error) {
+	keys := splitKeyPath(path)
+	value, ok := lookupIn(e.m, keys)
+	if !ok {
+		return nil, fmt.Errorf("reference not found: %s", path)
+	}
+	expanded, err := e.expand(value, path)
+	if err != nil {
+		return nil, err
+	}
+	if _, isString := value.(string); isString && len(keys) > 0 {
+		if _, err := editIn(e.m, keys, false, func(interface{}, bool) (interface{}, error) {
+			return expanded, nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
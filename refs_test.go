@@ -0,0 +1,52 @@
+package jsonmapper_v2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandRefs(t *testing.T) {
+	j, _ := NewJsonMapStr(`{
+		"defaults": {"host": "localhost", "port": 8080},
+		"api": {"url": "http://{{ defaults.host }}:{{defaults.port}}/v1", "port": "{{defaults.port}}"},
+		"client": {"endpoint": "{{api.url}}", "copy": "{{defaults}}"},
+		"list": ["{{list[1]}}!", "x"]
+	}`)
+	if err := j.ExpandRefs(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := j.FindString("client.endpoint"); got != "http://localhost:8080/v1" {
+		t.Errorf("client.endpoint = %q", got)
+	}
+	if got, err := j.FindInt("api.port"); err != nil || got != 8080 {
+		t.Errorf("api.port = %d, %v", got, err)
+	}
+	if got, _ := j.FindString("list[0]"); got != "x!" {
+		t.Errorf("list[0] = %q", got)
+	}
+
+	// Whole-value references are copies.
+	_ = j.Set("client.copy.host", "changed")
+	if got, _ := j.FindString("defaults.host"); got != "localhost" {
+		t.Errorf("defaults.host = %q", got)
+	}
+}
+
+func TestExpandRefsErrors(t *testing.T) {
+	for doc, want := range map[string]string{
+		`{"a": "{{b}}", "b": "x{{c}}", "c": "{{a}}"}`: "reference cycle: a -> b -> c -> a",
+		`{"a": {"b": "{{a}}"}}`:                       "reference cycle",
+		`{"a": "{{missing.key}}"}`:                    "reference not found: missing.key",
+	} {
+		j, _ := NewJsonMapStr(doc)
+		before := j.Print()
+		err := j.ExpandRefs()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", doc, err, want)
+		}
+		if j.Print() != before {
+			t.Errorf("%s: document changed on error", doc)
+		}
+	}
+}
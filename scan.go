@@ -0,0 +1,75 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// scanStructure walks the tokens of data without building values, reporting duplicate keys to
+// o.onDuplicate and enforcing the MaxDepth and MaxArrayLength limits. Nesting beyond the depth limit
+// is never descended into. Syntax errors are left for the decoder to report.
+func scanStructure(data []byte, o decodeOptions) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := scanValue(decoder, "", 1, o)
+	if _, syntax := err.(*json.SyntaxError); syntax || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil
+	}
+	return err
+}
+
+// scanValue consumes the next value from decoder, located at path and nested at the given depth.
+func scanValue(decoder *json.Decoder, path string, depth int, o decodeOptions) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') && token != json.Delim('[') {
+		return nil
+	}
+	if o.maxDepth > 0 && depth > o.maxDepth {
+		return fmt.Errorf("maximum depth of %d exceeded at %s", o.maxDepth, displayPath(path))
+	}
+
+	if token == json.Delim('{') {
+		seen := make(map[string]bool)
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			keyPath := joinKey(path, key)
+			if seen[key] && o.onDuplicate != nil {
+				if err := o.onDuplicate(keyPath); err != nil {
+					return err
+				}
+			}
+			seen[key] = true
+			if err := scanValue(decoder, keyPath, depth+1, o); err != nil {
+				return err
+			}
+		}
+	} else {
+		for i := 0; decoder.More(); i++ {
+			if o.maxArrayLength > 0 && i >= o.maxArrayLength {
+				return fmt.Errorf("array at %s exceeds maximum length of %d", displayPath(path), o.maxArrayLength)
+			}
+			if err := scanValue(decoder, joinIndex(path, i), depth+1, o); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// displayPath returns keyPath for use in error messages, naming the root explicitly.
+func displayPath(keyPath string) string {
+	if keyPath == "" {
+		return "root"
+	}
+	return keyPath
+}
@@ -0,0 +1,134 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+// InferSchema generates a JSON Schema (draft 2020-12) describing the current document, which is useful
+// for documenting undocumented API responses. Every object lists the types of its properties and requires
+// all keys it has. The item schema of an array is merged from all of its elements: a property is only
+// required if every object element has it, and elements of different types yield a list of types.
+// Numbers without a fractional part are typed as "integer", other numbers as "number".
+// The schema is returned as indented JSON.
+func (j *JsonMapper) InferSchema() ([]byte, error) {
+	j.materialize()
+	node := &schemaNode{}
+	node.add(j.m)
+	schema := node.toMap()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaNode accumulates the schema of one or more values seen at the same position.
+type schemaNode struct {
+	types      map[string]bool
+	properties map[string]*schemaNode
+	required   map[string]bool
+	items      *schemaNode
+}
+
+// add merges the schema of value into n.
+func (n *schemaNode) add(value interface{}) {
+	if n.types == nil {
+		n.types = make(map[string]bool)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if !n.types["object"] {
+			n.types["object"] = true
+			n.properties = make(map[string]*schemaNode)
+			n.required = make(map[string]bool, len(v))
+			for k := range v {
+				n.required[k] = true
+			}
+		} else {
+			for k := range n.required {
+				if _, ok := v[k]; !ok {
+					delete(n.required, k)
+				}
+			}
+		}
+		for k, child := range v {
+			property, ok := n.properties[k]
+			if !ok {
+				property = &schemaNode{}
+				n.properties[k] = property
+			}
+			property.add(child)
+		}
+	case []interface{}:
+		n.types["array"] = true
+		for _, item := range v {
+			if n.items == nil {
+				n.items = &schemaNode{}
+			}
+			n.items.add(item)
+		}
+	case string:
+		n.types["string"] = true
+	case bool:
+		n.types["boolean"] = true
+	case nil:
+		n.types["null"] = true
+	default:
+		if isInteger(v) {
+			n.types["integer"] = true
+		} else {
+			n.types["number"] = true
+		}
+	}
+}
+
+// toMap converts n into its JSON Schema representation.
+func (n *schemaNode) toMap() map[string]interface{} {
+	if n.types["number"] {
+		// Every integer is also a number.
+		delete(n.types, "integer")
+	}
+
+	schema := make(map[string]interface{})
+	types := make([]string, 0, len(n.types))
+	for t := range n.types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	if len(types) == 1 {
+		schema["type"] = types[0]
+	} else if len(types) > 1 {
+		schema["type"] = types
+	}
+
+	if n.types["object"] {
+		properties := make(map[string]interface{}, len(n.properties))
+		for k, property := range n.properties {
+			properties[k] = property.toMap()
+		}
+		schema["properties"] = properties
+		if len(n.required) > 0 {
+			required := make([]string, 0, len(n.required))
+			for k := range n.required {
+				required = append(required, k)
+			}
+			sort.Strings(required)
+			schema["required"] = required
+		}
+	}
+	if n.items != nil {
+		schema["items"] = n.items.toMap()
+	}
+	return schema
+}
+
+// isInteger reports whether value is a number without a fractional part.
+func isInteger(value interface{}) bool {
+	if n, ok := value.(json.Number); ok {
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+	}
+	f, err := convertToFloat64(value)
+	return err == nil && f == math.Trunc(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,51 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"id": 1, "price": 2.5, "tags": ["a"], "mixed": [1, 1.5, null],
+		"users": [{"name": "alice", "age": 30}, {"name": "bob"}], "empty": []}`)
+
+	data, err := j.InferSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := NewJsonMapBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := map[string]string{
+		"$schema":                     "https://json-schema.org/draft/2020-12/schema",
+		"type":                        "object",
+		"properties.id.type":          "integer",
+		"properties.price.type":       "number",
+		"properties.tags.items.type":  "string",
+		"properties.users.items.type": "object",
+		"properties.users.items.properties.age.type": "integer",
+	}
+	for path, want := range checks {
+		if got, err := schema.FindString(path); err != nil || got != want {
+			t.Errorf("%s = %q, %v", path, got, err)
+		}
+	}
+
+	assertJSON := func(path, want string) {
+		t.Helper()
+		value, err := schema.Find(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		got, _ := json.Marshal(value)
+		if string(got) != want {
+			t.Errorf("%s = %s, want %s", path, got, want)
+		}
+	}
+	assertJSON("properties.mixed.items.type", `["null","number"]`)
+	assertJSON("properties.users.items.required", `["name"]`)
+	assertJSON("required", `["empty","id","mixed","price","tags","users"]`)
+	assertJSON("properties.empty", `{"type":"array"}`)
+}
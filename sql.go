@@ -0,0 +1,51 @@
+package jsonmapper_v2
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner, so a JSON or JSONB column can be scanned directly into a JsonMapper:
+//
+//	var doc jsonmapper_v2.JsonMapper
+//	err := db.QueryRow("SELECT settings FROM users WHERE id = $1", id).Scan(&doc)
+//
+// src may be a []byte or a string holding a JSON object; a NULL column yields an empty document.
+// The document is replaced as a single mutation, so history and change callbacks see it like any other.
+// Returns an error if src has another type or is not a JSON object.
+func (j *JsonMapper) Scan(src interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		data = []byte("{}")
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("cannot scan %T into JsonMapper", src)
+	}
+
+	m, err := decodeObject(data, decodeOptions{})
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("JSON document is not an object")
+	}
+	return j.mutate("scan", "", nil, func() error {
+		j.m = m
+		j.lazy = false
+		return nil
+	})
+}
+
+// Value implements driver.Valuer, so a JsonMapper can be passed directly as a query argument
+// for a JSON or JSONB column. The document is written in compact form as with Print;
+// a nil *JsonMapper is written as NULL.
+func (j *JsonMapper) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return j.marshalJSON(j.printOptions, false)
+}
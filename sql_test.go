@@ -0,0 +1,57 @@
+package jsonmapper_v2
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*JsonMapper)(nil)
+	_ driver.Valuer = (*JsonMapper)(nil)
+)
+
+func TestScan(t *testing.T) {
+	var j JsonMapper
+	if err := j.Scan([]byte(`{"a": {"b": [1, 2]}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := j.Print(); got != `{"a":{"b":[1,2]}}` {
+		t.Errorf("Print() after Scan([]byte) = %s", got)
+	}
+
+	var ops []string
+	j.OnChange(func(op, path string, oldValue, newValue interface{}) { ops = append(ops, op) })
+	if err := j.Scan(`{"c": true}`); err != nil {
+		t.Fatal(err)
+	}
+	if got := j.Print(); got != `{"c":true}` || len(ops) != 1 || ops[0] != "scan" {
+		t.Errorf("Print() after Scan(string) = %s, ops = %v", got, ops)
+	}
+
+	if err := j.Scan(nil); err != nil || j.Print() != `{}` {
+		t.Errorf("Scan(nil) = %v, document %s", err, j.Print())
+	}
+
+	for _, src := range []interface{}{42, `[1, 2]`, `{"a":`, []byte(`null`)} {
+		if err := j.Scan(src); err == nil {
+			t.Errorf("Scan(%v) succeeded", src)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"b": 1, "a": "x"}`)
+	v, err := j.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := v.([]byte); !ok || string(data) != `{"a":"x","b":1}` {
+		t.Errorf("Value() = %v", v)
+	}
+
+	var missing *JsonMapper
+	if v, err := missing.Value(); v != nil || err != nil {
+		t.Errorf("Value() of nil mapper = %v, %v", v, err)
+	}
+}
@@ -0,0 +1,53 @@
+package jsonmapper_v2
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// JsonMapStream reads newline-delimited JSON (NDJSON / JSON Lines) records from an io.Reader one at a time,
+// so large log files can be processed record by record without loading them into memory.
+type JsonMapStream struct {
+	r    *bufio.Reader
+	line int
+	opts decodeOptions
+}
+
+// NewJsonMapStream returns a JsonMapStream reading records from r.
+// Options such as UseNumber control how each record is decoded.
+func NewJsonMapStream(r io.Reader, opts ...Option) *JsonMapStream {
+	return &JsonMapStream{r: bufio.NewReader(r), opts: resolveOptions(opts)}
+}
+
+// Next reads the next record and returns it as a new JsonMapper instance.
+// Blank lines are skipped. Each record must be a JSON object on a single line.
+// Returns io.EOF when no records remain, or an error naming the line number if a record cannot be parsed;
+// reading may continue with the following line after a parse error.
+func (s *JsonMapStream) Next() (*JsonMapper, error) {
+	for {
+		data, err := s.r.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return nil, err
+		}
+		s.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		m, err := decodeObject(data, s.opts)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", s.line, err)
+		}
+		if m == nil {
+			return nil, fmt.Errorf("line %d: record is not an object", s.line)
+		}
+		return &JsonMapper{m: m}, nil
+	}
+}
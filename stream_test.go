@@ -0,0 +1,34 @@
+package jsonmapper_v2
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJsonMapStream(t *testing.T) {
+	input := "{\"id\": 1, \"level\": \"info\"}\n\n{\"id\": 2, \"level\": \"error\"}\r\nnot json\n{\"id\": 3}"
+	stream := NewJsonMapStream(strings.NewReader(input))
+
+	var ids []int
+	var parseErrors int
+	for {
+		record, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !strings.HasPrefix(err.Error(), "line 4:") {
+				t.Errorf("unexpected error: %v", err)
+			}
+			parseErrors++
+			continue
+		}
+		id, _ := record.FindInt("id")
+		ids = append(ids, id)
+	}
+
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 || parseErrors != 1 {
+		t.Errorf("ids = %v, parse errors = %d", ids, parseErrors)
+	}
+}
@@ -0,0 +1,40 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NewJsonMapStruct initializes a new JsonMapper instance from a Go struct, map or pointer to either,
+// so existing typed configuration structs can be post-processed with path operations.
+// The value is marshaled with encoding/json, honoring json struct tags and MarshalJSON methods,
+// and decoded into the same representation as a parsed JSON document. Unlike NewJsonMapObject,
+// a map[string]interface{} is copied rather than used directly, so the mapper never shares state with v.
+// Options such as UseNumber control how the marshaled JSON is decoded.
+// Returns an error if v cannot be marshaled or does not marshal to a JSON object.
+func NewJsonMapStruct(v interface{}, opts ...Option) (*JsonMapper, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %v", v, err)
+	}
+	if firstByte(data) != '{' {
+		return nil, fmt.Errorf("%T does not marshal to a JSON object", v)
+	}
+	return newJsonMap(data, resolveOptions(opts))
+}
+
+// Decode converts the current, possibly modified, document into dst, which must be a non-nil pointer
+// to a struct, map or other value accepted by json.Unmarshal, closing the loop for load, edit and bind workflows.
+// The document is marshaled and unmarshaled with encoding/json, so json struct tags and UnmarshalJSON
+// methods are honored, unknown fields are ignored and fields missing from the document keep their values in dst.
+// Returns an error if dst is not a non-nil pointer or a value does not fit the type of its field.
+func (j *JsonMapper) Decode(dst interface{}) error {
+	data, err := json.Marshal(j.m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to decode into %T: %w", dst, err)
+	}
+	return nil
+}
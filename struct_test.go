@@ -0,0 +1,104 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testServerConfig struct {
+	Host    string            `json:"host"`
+	Port    int               `json:"port"`
+	TLS     *testTLSConfig    `json:"tls,omitempty"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Secret  string            `json:"-"`
+	Timeout int64             `json:"timeout_ms"`
+}
+
+type testTLSConfig struct {
+	Enabled bool   `json:"enabled"`
+	Cert    string `json:"cert"`
+}
+
+func TestNewJsonMapStruct(t *testing.T) {
+	cfg := testServerConfig{
+		Host:    "localhost",
+		Port:    8080,
+		TLS:     &testTLSConfig{Enabled: true, Cert: "server.pem"},
+		Tags:    []string{"a", "b"},
+		Secret:  "hidden",
+		Timeout: 9007199254740993,
+	}
+
+	j, err := NewJsonMapStruct(&cfg, UseNumber())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"host":"localhost","port":8080,"tags":["a","b"],"timeout_ms":9007199254740993,"tls":{"cert":"server.pem","enabled":true}}`
+	if got := j.Print(); got != want {
+		t.Errorf("Print() = %s, want %s", got, want)
+	}
+	if got, err := j.FindInt64("timeout_ms"); err != nil || got != 9007199254740993 {
+		t.Errorf("FindInt64() = %d, %v", got, err)
+	}
+	if got := j.FindStringOr("tags[1]", ""); got != "b" {
+		t.Errorf("FindStringOr() = %q", got)
+	}
+
+	m := map[string]interface{}{"a": map[string]interface{}{"b": 1}}
+	j, err = NewJsonMapStruct(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = j.Set("a.b", 2.0)
+	if m["a"].(map[string]interface{})["b"] != 1 {
+		t.Error("mapper shares state with the source map")
+	}
+}
+
+func TestNewJsonMapStructErrors(t *testing.T) {
+	for _, v := range []interface{}{nil, []int{1}, "text", 42, make(chan int)} {
+		if _, err := NewJsonMapStruct(v); err == nil {
+			t.Errorf("NewJsonMapStruct(%T) succeeded", v)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"host": "localhost", "port": 8080, "tags": ["a"], "extra": true}`, UseNumber())
+	_ = j.Set("port", 9090)
+	_ = j.Add("tls", map[string]interface{}{"enabled": true, "cert": "server.pem"})
+	_ = j.Add("tags[-1]", "b")
+
+	cfg := testServerConfig{Secret: "kept", Timeout: 5}
+	if err := j.Decode(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "localhost" || cfg.Port != 9090 || cfg.TLS == nil || !cfg.TLS.Enabled || cfg.TLS.Cert != "server.pem" {
+		t.Errorf("Decode() = %+v", cfg)
+	}
+	if len(cfg.Tags) != 2 || cfg.Tags[1] != "b" || cfg.Secret != "kept" || cfg.Timeout != 5 {
+		t.Errorf("Decode() = %+v", cfg)
+	}
+
+	lazy, _ := NewJsonMapLazy([]byte(`{"host": "h", "tls": {"enabled": true}}`))
+	var lazyCfg testServerConfig
+	if err := lazy.Decode(&lazyCfg); err != nil || lazyCfg.Host != "h" || lazyCfg.TLS == nil || !lazyCfg.TLS.Enabled {
+		t.Errorf("Decode() of lazy document = %+v, %v", lazyCfg, err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"port": "not a number"}`)
+
+	var cfg testServerConfig
+	err := j.Decode(&cfg)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field != "port" {
+		t.Errorf("Decode() error = %v", err)
+	}
+	if err := j.Decode(cfg); err == nil {
+		t.Error("Decode() into a non-pointer succeeded")
+	}
+}
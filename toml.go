@@ -0,0 +1,92 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// NewJsonMapTOML initializes a new JsonMapper instance from a TOML document.
+// Tables become objects, arrays become arrays, integers and floats become float64
+// and date-times become RFC 3339 strings, so TOML configuration files can be edited with the same API as JSON.
+// Returns an error if parsing fails.
+func NewJsonMapTOML(data []byte) (*JsonMapper, error) {
+	var v map[string]interface{}
+	if err := toml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	m, err := normalizeDocument(v, "TOML")
+	if err != nil {
+		return nil, err
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// WriteTOML saves the current structure to a file as TOML.
+// Whole numbers are written as TOML integers. TOML has no null value, so null object entries are omitted;
+// an error is returned if an array contains null.
+// Returns an error if encoding or writing the file fails.
+func (j *JsonMapper) WriteTOML(filePath string) error {
+	j.materialize()
+	v, err := tomlValue(j.m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal TOML: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return fmt.Errorf("failed to marshal TOML: %v", err)
+	}
+
+	err = os.WriteFile(filePath, buf.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return nil
+}
+
+// tomlValue prepares a value for the TOML encoder by dropping null object entries
+// and converting whole float64 numbers and json.Number values to int64 where possible.
+func tomlValue(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for k, child := range value {
+			if child == nil {
+				continue
+			}
+			converted, err := tomlValue(child)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = converted
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, child := range value {
+			if child == nil {
+				return nil, fmt.Errorf("TOML arrays cannot contain null")
+			}
+			converted, err := tomlValue(child)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = converted
+		}
+		return out, nil
+	case float64:
+		if n, ok := wholeNumber(value); ok {
+			return n, nil
+		}
+		return value, nil
+	case json.Number:
+		return nativeNumber(value), nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,57 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTOML(t *testing.T) {
+	j, err := NewJsonMapTOML([]byte(`
+title = "example"
+
+[server]
+host = "localhost"
+port = 8080
+ratio = 0.5
+started = 2024-01-02T03:04:05Z
+
+[[users]]
+name = "alice"
+
+[[users]]
+name = "bob"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port, err := j.FindInt("server.port"); err != nil || port != 8080 {
+		t.Errorf("port = %v, %v", port, err)
+	}
+	if name, _ := j.FindString("users[1].name"); name != "bob" {
+		t.Errorf("name = %q", name)
+	}
+	if started, _ := j.FindString("server.started"); started != "2024-01-02T03:04:05Z" {
+		t.Errorf("started = %q", started)
+	}
+
+	j.Add("server.debug", nil)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := j.WriteTOML(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	reloaded, err := NewJsonMapTOML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.Remove("server.debug")
+	if !reloaded.Equal(j) {
+		t.Errorf("round trip: got %s, want %s", reloaded.Print(), j.Print())
+	}
+
+	j.Add("list", []interface{}{1, nil})
+	if err := j.WriteTOML(path); err == nil {
+		t.Error("expected error for null in array")
+	}
+}
@@ -0,0 +1,79 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+)
+
+// Tx groups several mutations so they are applied to a JsonMapper atomically.
+// Mutations are staged on a private copy of the document and only become visible on Commit.
+// If any staged mutation fails, Commit returns that error and the document is left untouched.
+// A Tx must not be used after Commit or Rollback.
+type Tx struct {
+	j    *JsonMapper
+	work *JsonMapper
+	err  error
+	done bool
+}
+
+// Begin starts a new transaction on the JsonMapper.
+// Changes made to the document outside of the transaction between Begin and Commit
+// are overwritten when the transaction is committed.
+func (j *JsonMapper) Begin() *Tx {
+	return &Tx{j: j, work: j.Clone()}
+}
+
+// Add stages an Add of value at keyPath. See JsonMapper.Add.
+func (tx *Tx) Add(keyPath string, value interface{}) error {
+	return tx.apply(func() error { return tx.work.add(keyPath, value) })
+}
+
+// Set stages a Set of value at keyPath. See JsonMapper.Set.
+func (tx *Tx) Set(keyPath string, value interface{}) error {
+	return tx.apply(func() error { return tx.work.set(keyPath, value) })
+}
+
+// Remove stages a Remove of keyPath. See JsonMapper.Remove.
+func (tx *Tx) Remove(keyPath string) error {
+	return tx.apply(func() error { return tx.work.remove(keyPath) })
+}
+
+// Commit applies all staged mutations to the document as a single mutation.
+// Returns the first error encountered while staging, in which case nothing is applied.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return fmt.Errorf("transaction already finished")
+	}
+	tx.done = true
+	if tx.err != nil {
+		return tx.err
+	}
+	return tx.j.mutate("commit", "", nil, func() error {
+		tx.j.m = tx.work.m
+		return nil
+	})
+}
+
+// Rollback discards all staged mutations.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return fmt.Errorf("transaction already finished")
+	}
+	tx.done = true
+	tx.work = nil
+	return nil
+}
+
+// apply runs a staged mutation and remembers the first failure.
+func (tx *Tx) apply(fn func() error) error {
+	if tx.done {
+		return fmt.Errorf("transaction already finished")
+	}
+	if tx.err != nil {
+		return tx.err
+	}
+	if err := fn(); err != nil {
+		tx.err = err
+		return err
+	}
+	return nil
+}
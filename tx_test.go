@@ -0,0 +1,47 @@
+package jsonmapper_v2
+
+import (
+	"testing"
+)
+
+func TestTxCommit(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+
+	tx := j.Begin()
+	if err := tx.Set("testData.s2[1].name", "bobby"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Remove("testData.bool"); err != nil {
+		t.Fatal(err)
+	}
+	if j.FindStringOr("testData.s2.1.name", "") != "bob" {
+		t.Fatal("staged mutation leaked before commit")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := j.FindStringOr("testData.s2.1.name", ""); v != "bobby" {
+		t.Errorf("commit set: got %q", v)
+	}
+	if _, err := j.Find("testData.bool"); err == nil {
+		t.Error("commit remove: key still present")
+	}
+}
+
+func TestTxFailureLeavesDocumentUntouched(t *testing.T) {
+	j, _ := NewJsonMapStr(test_nested_json_string)
+	before := j.Print()
+
+	tx := j.Begin()
+	_ = tx.Set("testData.number", 1)
+	if err := tx.Set("testData.missing", 1); err == nil {
+		t.Fatal("expected error setting a missing key")
+	}
+	if err := tx.Commit(); err == nil {
+		t.Fatal("expected commit to fail")
+	}
+	if j.Print() != before {
+		t.Error("document changed after failed transaction")
+	}
+}
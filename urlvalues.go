@@ -0,0 +1,153 @@
+package jsonmapper_v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NewJsonMapURLValues initializes a new JsonMapper instance from URL query parameters or a parsed form,
+// bridging form submissions to JSON APIs. Parameter names are key paths in dot notation ("user.name"),
+// bracket notation ("user[name]") or a mix of both; numeric segments such as "items[0][id]" create arrays.
+// A name ending in "[]", such as "tags[]", always yields an array of its values, as does a name given
+// more than once; any other parameter yields a single string. All values are strings.
+// Returns an error if a name is malformed or two names conflict, e.g. "a=1" together with "a.b=2".
+func NewJsonMapURLValues(values url.Values) (*JsonMapper, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m := make(map[string]interface{})
+	for _, name := range names {
+		keyPath, isArray, err := queryKeyPath(name)
+		if err != nil {
+			return nil, err
+		}
+
+		params := values[name]
+		var value interface{}
+		if isArray || len(params) > 1 {
+			items := make([]interface{}, len(params))
+			for i, param := range params {
+				items[i] = param
+			}
+			value = items
+		} else if len(params) == 1 {
+			value = params[0]
+		} else {
+			value = ""
+		}
+
+		if _, err := unflattenInsert(m, splitKeyPath(keyPath), value, name); err != nil {
+			return nil, err
+		}
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// queryKeyPath converts a query parameter name to a keyPath in dot notation, rewriting every
+// bracketed segment "[key]" to ".key". isArray is true if the name ends in "[]".
+func queryKeyPath(name string) (keyPath string, isArray bool, err error) {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] != '[' {
+			b.WriteByte(name[i])
+			continue
+		}
+		end := strings.IndexByte(name[i:], ']')
+		if end < 0 {
+			return "", false, fmt.Errorf("%w: unclosed bracket in parameter %s", ErrInvalidPath, name)
+		}
+		segment := name[i+1 : i+end]
+		i += end
+		if segment == "" {
+			if i != len(name)-1 {
+				return "", false, fmt.Errorf("%w: [] must end parameter %s", ErrInvalidPath, name)
+			}
+			isArray = true
+			continue
+		}
+		b.WriteByte('.')
+		b.WriteString(segment)
+	}
+
+	keyPath = b.String()
+	for _, key := range splitKeyPath(keyPath) {
+		if key == "" {
+			return "", false, fmt.Errorf("%w: empty segment in parameter %s", ErrInvalidPath, name)
+		}
+	}
+	if keyPath == "" {
+		return "", false, fmt.Errorf("%w: empty parameter name", ErrInvalidPath)
+	}
+	return keyPath, isArray, nil
+}
+
+// ToURLValues converts the document into URL query parameters, the inverse of NewJsonMapURLValues.
+// Object keys use dot notation and array elements bracket notation, e.g. "items[0].id", except that
+// arrays holding only scalars become a repeated "name[]" parameter. Strings are used as they are,
+// numbers and booleans in their JSON form and null as an empty string.
+// Empty objects and arrays have no representation as parameters and are omitted.
+func (j *JsonMapper) ToURLValues() url.Values {
+	j.materialize()
+	values := make(url.Values)
+
+	var convert func(value interface{}, path string)
+	convert = func(value interface{}, path string) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for _, k := range sortedKeys(v) {
+				convert(v[k], joinKey(path, k))
+			}
+		case []interface{}:
+			if scalarItems(v) {
+				for _, item := range v {
+					values.Add(path+"[]", queryValue(item))
+				}
+				return
+			}
+			for i, item := range v {
+				convert(item, joinIndex(path, i))
+			}
+		default:
+			values.Add(path, queryValue(v))
+		}
+	}
+
+	convert(j.m, "")
+	return values
+}
+
+// scalarItems reports whether items contains no objects or arrays.
+func scalarItems(items []interface{}) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+// queryValue formats a scalar as a query parameter value.
+func queryValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case json.Number:
+		return string(value)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
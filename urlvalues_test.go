@@ -0,0 +1,65 @@
+package jsonmapper_v2
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestNewJsonMapURLValues(t *testing.T) {
+	values, _ := url.ParseQuery("user[name]=alice&user.email=a%40example.com&tags[]=x&tags[]=y&single[]=z" +
+		"&items[0][id]=1&items[1].id=2&items[1][qty]=3&color=red&color=blue&empty=")
+
+	j, err := NewJsonMapURLValues(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"color":["red","blue"],"empty":"","items":[{"id":"1"},{"id":"2","qty":"3"}],` +
+		`"single":["z"],"tags":["x","y"],"user":{"email":"a@example.com","name":"alice"}}`
+	if got := j.Print(); got != want {
+		t.Errorf("Print() = %s, want %s", got, want)
+	}
+}
+
+func TestNewJsonMapURLValuesErrors(t *testing.T) {
+	for _, query := range []string{"a=1&a.b=2", "a=1&a[]=2", "a[b=1", "a[]x=1", "a..b=1", "[]=1", "a[-1]=1"} {
+		values, _ := url.ParseQuery(query)
+		if _, err := NewJsonMapURLValues(values); err == nil {
+			t.Errorf("NewJsonMapURLValues(%s) succeeded", query)
+		}
+	}
+
+	values, _ := url.ParseQuery("a[b=1")
+	if _, err := NewJsonMapURLValues(values); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("error = %v, want ErrInvalidPath", err)
+	}
+}
+
+func TestToURLValues(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"user": {"name": "alice", "age": 30, "admin": false, "manager": null},
+		"tags": ["x", 1], "items": [{"id": 1}, {"id": 2, "sub": [true]}], "none": {}, "nothing": []}`)
+
+	got := j.ToURLValues()
+	want := url.Values{
+		"user.name":      {"alice"},
+		"user.age":       {"30"},
+		"user.admin":     {"false"},
+		"user.manager":   {""},
+		"tags[]":         {"x", "1"},
+		"items[0].id":    {"1"},
+		"items[1].id":    {"2"},
+		"items[1].sub[]": {"true"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToURLValues() = %v, want %v", got, want)
+	}
+
+	back, err := NewJsonMapURLValues(url.Values{"user.name": {"alice"}, "tags[]": {"x"}, "items[0].id": {"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundTrip, _ := NewJsonMapURLValues(back.ToURLValues()); !back.Equal(roundTrip) {
+		t.Errorf("round trip = %s, want %s", roundTrip.Print(), back.Print())
+	}
+}
@@ -0,0 +1,62 @@
+package jsonmapper_v2
+
+import (
+	"errors"
+	"sort"
+)
+
+// StopWalk can be returned by a WalkFunc to stop the traversal early.
+// Walk then returns nil instead of the error.
+var StopWalk = errors.New("stop walk")
+
+// WalkFunc is called by Walk for every value in the document.
+// path is the keyPath of the value in the notation used by FindAllWithCondition, and is empty for the root.
+// Returning descend=false skips the children of an object or array; it has no effect on scalar values.
+// Returning a non-nil error stops the traversal.
+type WalkFunc func(path string, value interface{}) (descend bool, err error)
+
+// Walk traverses the whole document depth-first, calling fn for the root and every nested value.
+// Object keys are visited in sorted order and array elements in index order, so traversal is deterministic.
+// If fn returns StopWalk, Walk stops and returns nil; any other error is returned as is.
+func (j *JsonMapper) Walk(fn WalkFunc) error {
+	j.materialize()
+	err := walkValue(j.m, "", fn)
+	if err == StopWalk {
+		return nil
+	}
+	return err
+}
+
+// walkValue visits value and, if requested by fn, its children.
+func walkValue(value interface{}, path string, fn WalkFunc) error {
+	descend, err := fn(path, value)
+	if err != nil || !descend {
+		return err
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			if err := walkValue(v[k], joinKey(path, k), fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			if err := walkValue(child, joinIndex(path, i), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,27 @@
+package jsonmapper_v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"b": {"x": 1, "y": 2}, "a": [1, 2], "c": 3}`)
+
+	var visited []string
+	err := j.Walk(func(path string, value interface{}) (bool, error) {
+		if path == "c" {
+			return false, StopWalk
+		}
+		visited = append(visited, path)
+		return path != "b", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"", "a", "a[0]", "a[1]", "b"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("got %v, want %v", visited, want)
+	}
+}
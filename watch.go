@@ -0,0 +1,67 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is how often WatchFile checks the file for changes.
+const DefaultWatchInterval = time.Second
+
+// WatchFile watches a JSON file for live-reloadable configuration. The file is polled every
+// DefaultWatchInterval, and whenever its modification time or size changes it is parsed again
+// and onReload is called with the new JsonMapper, or with the error if the file cannot be read or parsed,
+// e.g. because it was removed or is only partially written. A failing file is reported once until it changes again.
+// The initial contents are not reported; load them with NewJsonMapFile first.
+// Options such as UseNumber control how the file is decoded.
+//
+// onReload is called from a separate goroutine, one call at a time. Calling the returned stop function
+// ends the watch: a reload already in progress completes, but no further reloads start. stop may be called more than once.
+func WatchFile(filePath string, onReload func(*JsonMapper, error), opts ...Option) (stop func()) {
+	return WatchFileInterval(filePath, DefaultWatchInterval, onReload, opts...)
+}
+
+// WatchFileInterval is like WatchFile but polls the file every interval.
+func WatchFileInterval(filePath string, interval time.Duration, onReload func(*JsonMapper, error), opts ...Option) (stop func()) {
+	done := make(chan struct{})
+	last, err := os.Stat(filePath)
+	missing := err != nil
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(filePath)
+			if err != nil {
+				if !missing {
+					onReload(nil, err)
+				}
+				last, missing = nil, true
+				continue
+			}
+			if !missing && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+				continue
+			}
+			last, missing = info, false
+
+			select {
+			case <-done:
+				return
+			default:
+			}
+			onReload(NewJsonMapFile(filePath, opts...))
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
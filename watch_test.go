@@ -0,0 +1,83 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type reload struct {
+	j   *JsonMapper
+	err error
+}
+
+func TestWatchFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filePath, []byte(`{"port": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloads := make(chan reload, 10)
+	stop := WatchFileInterval(filePath, 5*time.Millisecond, func(j *JsonMapper, err error) {
+		reloads <- reload{j, err}
+	})
+	defer stop()
+
+	next := func() reload {
+		t.Helper()
+		select {
+		case r := <-reloads:
+			return r
+		case <-time.After(2 * time.Second):
+			t.Fatal("no reload")
+			return reload{}
+		}
+	}
+	expectNone := func() {
+		t.Helper()
+		select {
+		case r := <-reloads:
+			t.Fatalf("unexpected reload: %v", r.err)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	expectNone()
+
+	if err := os.WriteFile(filePath, []byte(`{"port": 22}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r := next(); r.err != nil || r.j.FindIntOr("port", 0) != 22 {
+		t.Errorf("reload = %v, %v", r.j, r.err)
+	}
+
+	if err := os.WriteFile(filePath, []byte(`{"port":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r := next(); r.err == nil {
+		t.Error("expected parse error")
+	}
+	expectNone()
+
+	if err := os.Remove(filePath); err != nil {
+		t.Fatal(err)
+	}
+	if r := next(); r.err == nil || !os.IsNotExist(r.err) {
+		t.Errorf("reload error = %v, want not exist", r.err)
+	}
+	expectNone()
+
+	if err := os.WriteFile(filePath, []byte(`{"port": 333}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r := next(); r.err != nil || r.j.FindIntOr("port", 0) != 333 {
+		t.Errorf("reload = %v, %v", r.j, r.err)
+	}
+
+	stop()
+	stop()
+	time.Sleep(20 * time.Millisecond)
+	_ = os.WriteFile(filePath, []byte(`{"port": 4444}`), 0644)
+	expectNone()
+}
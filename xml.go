@@ -0,0 +1,232 @@
+package jsonmapper_v2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// XMLOption configures how NewJsonMapXML and WriteXML map between XML and the JSON structure.
+type XMLOption func(*xmlOptions)
+
+type xmlOptions struct {
+	attrPrefix string
+	textKey    string
+	rootName   string
+}
+
+// XMLAttrPrefix sets the prefix that marks object keys holding XML attributes. The default is "@",
+// so <user id="1"> maps to {"user": {"@id": "1"}}. An empty prefix is not allowed and keeps the default.
+func XMLAttrPrefix(prefix string) XMLOption {
+	return func(o *xmlOptions) {
+		if prefix != "" {
+			o.attrPrefix = prefix
+		}
+	}
+}
+
+// XMLTextKey sets the key holding the character data of elements that also have attributes or children.
+// The default is "#text".
+func XMLTextKey(key string) XMLOption {
+	return func(o *xmlOptions) {
+		if key != "" {
+			o.textKey = key
+		}
+	}
+}
+
+// XMLRoot sets the name of the root element written by WriteXML when the structure does not consist
+// of a single top-level object. The default is "root".
+func XMLRoot(name string) XMLOption {
+	return func(o *xmlOptions) {
+		if name != "" {
+			o.rootName = name
+		}
+	}
+}
+
+func resolveXMLOptions(opts []XMLOption) xmlOptions {
+	o := xmlOptions{attrPrefix: "@", textKey: "#text", rootName: "root"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewJsonMapXML initializes a new JsonMapper instance from an XML document, so legacy XML payloads
+// can be queried and edited with Find, Add, Remove and the other methods.
+// The root element becomes the single top-level key. An element with neither attributes nor child elements
+// becomes a string holding its trimmed text; otherwise it becomes an object whose keys are its attributes
+// (prefixed, see XMLAttrPrefix), its child elements and, if present, its text (see XMLTextKey).
+// Repeated child elements are collected into an array. Namespaces are dropped and all values are strings.
+// Returns an error if the XML is malformed or has no root element.
+func NewJsonMapXML(data []byte, opts ...XMLOption) (*JsonMapper, error) {
+	o := resolveXMLOptions(opts)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("XML document has no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start, o)
+			if err != nil {
+				return nil, err
+			}
+			return &JsonMapper{m: map[string]interface{}{start.Name.Local: value}}, nil
+		}
+	}
+}
+
+// decodeXMLElement converts the element opened by start, consuming tokens up to its end element.
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement, o xmlOptions) (interface{}, error) {
+	node := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		node[o.attrPrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	hasChildren := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t, o)
+			if err != nil {
+				return nil, err
+			}
+			hasChildren = true
+			name := t.Name.Local
+			switch existing := node[name].(type) {
+			case nil:
+				node[name] = child
+			case []interface{}:
+				node[name] = append(existing, child)
+			default:
+				node[name] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(node) == 0 && !hasChildren {
+				return content, nil
+			}
+			if content != "" {
+				node[o.textKey] = content
+			}
+			return node, nil
+		}
+	}
+}
+
+// WriteXML saves the current structure to a file as indented XML.
+// If the structure consists of a single top-level key, that key names the root element;
+// otherwise the structure is wrapped in a root element named by XMLRoot.
+// Object keys starting with the attribute prefix are written as attributes, the text key as character data,
+// and arrays as repeated elements. Keys are written in sorted order.
+// Returns an error if encoding or writing the file fails.
+func (j *JsonMapper) WriteXML(filePath string, opts ...XMLOption) error {
+	j.materialize()
+	o := resolveXMLOptions(opts)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+
+	var err error
+	if keys := sortedKeys(j.m); len(keys) == 1 {
+		err = encodeXMLElement(encoder, keys[0], j.m[keys[0]], o)
+	} else {
+		err = encodeXMLElement(encoder, o.rootName, j.m, o)
+	}
+	if err == nil {
+		err = encoder.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal XML: %v", err)
+	}
+	buf.WriteByte('\n')
+
+	err = os.WriteFile(filePath, buf.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return nil
+}
+
+// encodeXMLElement writes value as one element named name, or as one element per item if value is an array.
+func encodeXMLElement(encoder *xml.Encoder, name string, value interface{}, o xmlOptions) error {
+	if items, ok := value.([]interface{}); ok {
+		for _, item := range items {
+			if err := encodeXMLElement(encoder, name, item, o); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	object, isObject := value.(map[string]interface{})
+	if isObject {
+		for _, k := range sortedKeys(object) {
+			if strings.HasPrefix(k, o.attrPrefix) {
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: strings.TrimPrefix(k, o.attrPrefix)}, Value: xmlText(object[k])})
+			}
+		}
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if isObject {
+		if text, ok := object[o.textKey]; ok {
+			if err := encoder.EncodeToken(xml.CharData(xmlText(text))); err != nil {
+				return err
+			}
+		}
+		for _, k := range sortedKeys(object) {
+			if k == o.textKey || strings.HasPrefix(k, o.attrPrefix) {
+				continue
+			}
+			if err := encodeXMLElement(encoder, k, object[k], o); err != nil {
+				return err
+			}
+		}
+	} else if value != nil {
+		if err := encoder.EncodeToken(xml.CharData(xmlText(value))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// xmlText formats a scalar value as XML character data.
+func xmlText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
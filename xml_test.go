@@ -0,0 +1,71 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestXML(t *testing.T) {
+	j, err := NewJsonMapXML([]byte(`<?xml version="1.0"?>
+<catalog xmlns="urn:example" version="2">
+  <book id="b1"><title>Go</title><price>10</price></book>
+  <book id="b2"><title lang="en">XML</title><price>20</price></book>
+  <note>plain</note>
+</catalog>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"catalog.@version":            "2",
+		"catalog.book[0].@id":         "b1",
+		"catalog.book[1].price":       "20",
+		"catalog.book[1].title.#text": "XML",
+		"catalog.book[1].title.@lang": "en",
+		"catalog.note":                "plain",
+	} {
+		if got, err := j.FindString(path); err != nil || got != want {
+			t.Errorf("%s = %q, %v; want %q", path, got, err, want)
+		}
+	}
+
+	j.Remove("catalog.note")
+	path := filepath.Join(t.TempDir(), "catalog.xml")
+	if err := j.WriteXML(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	reloaded, err := NewJsonMapXML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Equal(j) {
+		t.Errorf("round trip: got %s, want %s", reloaded.Print(), j.Print())
+	}
+
+	custom, err := NewJsonMapXML([]byte(`<a x="1">hi</a>`), XMLAttrPrefix("-"), XMLTextKey("_"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":{"-x":"1","_":"hi"}}`; custom.Print() != want {
+		t.Errorf("custom options: got %s, want %s", custom.Print(), want)
+	}
+
+	if _, err := NewJsonMapXML([]byte(`<a><b></a>`)); err == nil {
+		t.Error("expected error for malformed XML")
+	}
+}
+
+func TestWriteXMLRoot(t *testing.T) {
+	j, _ := NewJsonMapStr(`{"a": 1, "b": [true, null]}`)
+	path := filepath.Join(t.TempDir(), "doc.xml")
+	if err := j.WriteXML(path, XMLRoot("doc")); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<doc>\n  <a>1</a>\n  <b>true</b>\n  <b></b>\n</doc>\n"
+	if string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+}
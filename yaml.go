@@ -0,0 +1,45 @@
+package jsonmapper_v2
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewJsonMapYAML initializes a new JsonMapper instance from a YAML document.
+// The document is converted to the same representation used for JSON: mappings become objects,
+// sequences become arrays, numbers become float64 and timestamps become RFC 3339 strings,
+// so Find, Add, Remove and the other methods work on YAML configuration files unchanged.
+// Returns an error if parsing fails or the top-level value is not a mapping.
+func NewJsonMapYAML(data []byte) (*JsonMapper, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return &JsonMapper{m: map[string]interface{}{}}, nil
+	}
+	m, err := normalizeDocument(v, "YAML")
+	if err != nil {
+		return nil, err
+	}
+	return &JsonMapper{m: m}, nil
+}
+
+// WriteYAML saves the current structure to a file as YAML, with object keys in sorted order.
+// Returns an error if encoding or writing the file fails.
+func (j *JsonMapper) WriteYAML(filePath string) error {
+	j.materialize()
+	data, err := yaml.Marshal(nativeNumbers(j.m))
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %v", err)
+	}
+
+	err = os.WriteFile(filePath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package jsonmapper_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestYAML(t *testing.T) {
+	j, err := NewJsonMapYAML([]byte(`
+server:
+  host: localhost
+  port: 8080
+  tags: [a, b]
+  started: 2024-01-02T03:04:05Z
+1: one
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port, err := j.FindInt("server.port"); err != nil || port != 8080 {
+		t.Errorf("port = %v, %v", port, err)
+	}
+	if tag, _ := j.FindString("server.tags[1]"); tag != "b" {
+		t.Errorf("tag = %q", tag)
+	}
+	if started, _ := j.FindString("server.started"); started != "2024-01-02T03:04:05Z" {
+		t.Errorf("started = %q", started)
+	}
+	if one, _ := j.FindString("1"); one != "one" {
+		t.Errorf("non-string key = %q", one)
+	}
+
+	j.Add("server.port", 9090)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := j.WriteYAML(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	reloaded, err := NewJsonMapYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Equal(j) {
+		t.Errorf("round trip: got %s, want %s", reloaded.Print(), j.Print())
+	}
+
+	if _, err := NewJsonMapYAML([]byte(`- a`)); err == nil {
+		t.Error("expected error for a non-mapping document")
+	}
+}